@@ -1,76 +1,96 @@
 package fast
 
 import (
-	"crypto/sha256"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"path"
 	"reflect"
+	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/esequiel378/fast/internal/validator"
 	"github.com/gofiber/fiber/v2"
 )
 
-type App struct {
+// config holds the settings shared by every handler registered on an App
+type config struct {
 	validator validator.Validator
+	// validateAll validates struct inputs and outputs without `validate` tags,
+	// set when the rules are not declared by tags
+	validateAll bool
+	// structValidations are the types with a struct level validation,
+	// validated even without `validate` tags
+	structValidations map[reflect.Type]bool
+	// customValidations are the tags registered with WithValidation
+	customValidations map[string]bool
+	problemDetails    bool
+	errorHandler      ErrorHandler
+	errorMappers      []ErrorMapper
+	// outputValidation is the default output validation policy of endpoints
+	outputValidation OutputValidationPolicy
+	// development enables the checks of WithDevelopmentMode
+	development bool
+	// security is the security requirement documented for the handlers
+	security SecurityRequirement
+}
+
+type App struct {
+	config    *config
 	server    *fiber.App
 	path      string
 	apiSchema *OpenAPIGenerator
+	routes    *routeTable
+	// openAPI is the config set with WithOpenAPI
+	openAPI *OpenAPIConfig
+	// openAPITypes are the schemas set with WithOpenAPIType
+	openAPITypes map[reflect.Type]SchemaObject
+	// securitySchemes are the schemes set with WithSecurityScheme
+	securitySchemes map[string]SecuritySchemeObject
+	// err collects the errors of the options, returned by New
+	err error
+}
+
+// validates reports whether a struct must be passed to the validator, given
+// whether it declares `validate` tags and the struct types reachable from it
+func (cfg *config) validates(rules bool, types []reflect.Type) bool {
+	if rules || cfg.validateAll {
+		return true
+	}
+
+	for _, t := range types {
+		if cfg.structValidations[t] {
+			return true
+		}
+	}
+
+	return false
 }
 
 // WithFiberApp sets the fiber app to use.
-// This is useful to pre-configure the fiber app
+// This is useful to pre-configure the fiber app.
+// Its ErrorHandler is kept, so errors raised by Fiber itself, e.g. for
+// unrouted requests, are not rendered as problem details.
 func WithFiberApp(app *fiber.App) func(*App) {
 	return func(a *App) {
 		a.server = app
 	}
 }
 
+// WithRouteConflictPolicy sets what happens when a route conflicts with
+// a registered one. Defaults to WarnOnConflict.
+func WithRouteConflictPolicy(policy ConflictPolicy) func(*App) {
+	return func(a *App) {
+		a.routes.policy = policy
+	}
+}
+
 // WithExperimentalOpenAPISchema enables the OpenAPI schema generator
-// and serves the schema at /swagger.json and Swagger UI at /swagger
+// and serves the schema at /swagger.json and Swagger UI at /swagger.
+// It is WithOpenAPI with the default config.
 // WARN: This is experimental and not recommended for production use
 func WithExperimentalOpenAPISchema() func(*App) {
-	return func(a *App) {
-		a.apiSchema = NewOpenAPIGenerator(OpenAPIInfo{
-			Title:       "Fast",
-			Description: "Fast",
-			Version:     "0.0.1",
-		})
-
-		// Endpoint to serve the OpenAPI JSON
-		a.server.Get("/swagger.json", func(c *fiber.Ctx) error {
-			schema, err := a.apiSchema.GenerateJSON()
-			if err != nil {
-				return c.Status(500).JSON(map[string]string{
-					"error": "Failed to generate OpenAPI schema",
-				})
-			}
-
-			// Generate ETag based on content
-			hash := sha256.Sum256([]byte(schema))
-			etag := fmt.Sprintf(`"%x"`, hash[:])
-
-			// Check if client sent If-None-Match header
-			if c.Get("If-None-Match") == etag {
-				return c.Status(304).Send(nil) // Not Modified
-			}
-
-			// Send full response with ETag
-			c.Set("ETag", etag)
-			// TODO: Add cache control based on environment
-			// c.Set("Cache-Control", "max-age=3600") // Cache for 1 hour
-			c.Set("Content-Type", "application/json")
-
-			return c.SendString(schema)
-		})
-
-		// Serve Swagger UI
-		a.server.Get("/swagger", func(c *fiber.Ctx) error {
-			c.Set("Content-Type", "text/html")
-			return c.SendString(swaggerUIHTML)
-		})
-	}
+	return WithOpenAPI(OpenAPIConfig{})
 }
 
 func New(opts ...func(*App)) (App, error) {
@@ -79,18 +99,45 @@ func New(opts ...func(*App)) (App, error) {
 		return App{}, err
 	}
 
-	server := fiber.New()
+	cfg := &config{
+		validator: v,
+	}
+
+	// Requests that do not reach an endpoint, e.g. unrouted ones,
+	// are rendered by the error handler of the fiber app
+	server := fiber.New(fiber.Config{
+		ErrorHandler: cfg.fiberErrorHandler,
+	})
 
 	instance := App{
-		validator: v,
-		server:    server,
-		path:      "",
+		config: cfg,
+		server: server,
+		path:   "",
+		routes: &routeTable{},
 	}
 
 	for _, opt := range opts {
 		opt(&instance)
 	}
 
+	if instance.err != nil {
+		return App{}, instance.err
+	}
+
+	if instance.apiSchema != nil {
+		instance.apiSchema.problemDetails = instance.config.problemDetails
+
+		for t, schema := range instance.openAPITypes {
+			instance.apiSchema.RegisterType(t, schema)
+		}
+
+		for name, scheme := range instance.securitySchemes {
+			instance.apiSchema.RegisterSecurityScheme(name, scheme)
+		}
+
+		instance.serveOpenAPI(*instance.openAPI)
+	}
+
 	return instance, nil
 }
 
@@ -99,72 +146,215 @@ func New(opts ...func(*App)) (App, error) {
 //	app.Listen(":8080")
 //	app.Listen("127.0.0.1:8080")
 func (a App) Listen(addr string) error {
-	// TODO: Improve endpoints listing on startup
-	data, _ := json.MarshalIndent(a.server.Stack(), "", "  ")
-	fmt.Print(string(data))
+	for _, route := range a.Routes() {
+		fmt.Printf("%-7s %s -> %s\n", route.Method, route.Path, route.Handler)
+	}
+
 	return a.server.Listen(addr)
 }
 
+// Routes returns the routes registered on the app, in registration order
+func (a App) Routes() []Route {
+	return slices.Clone(a.routes.routes)
+}
+
 var handlerReturnType = reflect.TypeOf((*Handler)(nil)).Elem()
 
-// MustRegister registers a handler to the app
-// The handler must be a struct with Hanlder methods.
-func (a App) MustRegister(prefix string, handler any, middlewares ...Middleware) {
-	mustValidateAndRegisterHandler(
+// Register registers a handler to the app
+// The handler must be a struct with Handle methods.
+// It returns an error if the handler is invalid or, with the ErrorOnConflict
+// policy, if any of its routes conflicts with a registered one.
+func (a App) Register(prefix string, handler any, middlewares ...Middleware) error {
+	return validateAndRegisterHandler(
 		path.Join(a.path, prefix),
 		handler,
 		a.server.Group(prefix),
-		a.validator,
+		a.config,
 		a.apiSchema,
+		a.routes,
+		0,
 		middlewares...,
 	)
 }
 
+// MustRegister registers a handler to the app
+// The handler must be a struct with Hanlder methods.
+// It panics if the handler can not be registered.
+func (a App) MustRegister(prefix string, handler any, middlewares ...Middleware) {
+	if err := a.Register(prefix, handler, middlewares...); err != nil {
+		panic(err)
+	}
+}
+
 // Group creates a new group of routes
 func (a App) Group(prefix string, middlewares ...Middleware) Group {
 	return Group{
 		router:      a.server.Group(prefix),
-		validator:   a.validator,
+		config:      a.config,
 		path:        path.Join(a.path, prefix),
 		apiSchema:   a.apiSchema,
+		routes:      a.routes,
+		id:          a.routes.newGroup(),
 		middlewares: middlewares,
 	}
 }
 
-func mustValidateAndRegisterHandler(
-	path string,
+func validateAndRegisterHandler(
+	prefix string,
 	handler any,
 	router fiber.Router,
-	validator validator.Validator,
+	config *config,
 	apiSchema *OpenAPIGenerator,
+	routes *routeTable,
+	group int,
 	middlewares ...Middleware,
-) {
+) error {
 	handlerType := reflect.TypeOf(handler)
-	if handlerType.Kind() != reflect.Struct {
-		panic("handler is not a struct")
+	if handlerType == nil || handlerType.Kind() != reflect.Struct {
+		return &RegistrationError{
+			Handler: fmt.Sprint(handlerType),
+			Reason:  ErrHandlerNotStruct,
+		}
+	}
+
+	if err := validatePath(prefix); err != nil {
+		return &RegistrationError{
+			Handler: handlerType.String(),
+			Reason:  err,
+		}
 	}
 
 	handlerValue := reflect.ValueOf(handler)
 
+	var (
+		errs       []error
+		handlers   []Handler
+		candidates []Route
+	)
+
 	for i := range handlerType.NumMethod() {
 		method := handlerType.Method(i)
 
-		hasCorrectName := strings.HasPrefix(method.Name, "Handle")
-		hasCorrectReturnType := method.Type.NumOut() == 1 && method.Type.Out(0).Implements(handlerReturnType)
+		if !strings.HasPrefix(method.Name, "Handle") {
+			continue
+		}
+
+		name := handlerType.String() + "." + method.Name
+
+		// The receiver is the only argument of the method
+		hasCorrectSignature := method.Type.NumIn() == 1 &&
+			method.Type.NumOut() == 1 &&
+			method.Type.Out(0).Implements(handlerReturnType)
 
-		if !hasCorrectName || !hasCorrectReturnType {
+		if !hasCorrectSignature {
+			errs = append(errs, &RegistrationError{
+				Handler: name,
+				Reason:  ErrInvalidHandlerSignature,
+			})
 			continue
 		}
 
 		handler, ok := method.Func.Call([]reflect.Value{handlerValue})[0].Interface().(Handler)
-		if !ok {
-			panic("methods starting with `Handle` must return fast.Handler")
+		if !ok || isNilPointer(handler) {
+			errs = append(errs, &RegistrationError{
+				Handler: name,
+				Reason:  ErrNilHandler,
+			})
+			continue
+		}
+
+		route := Route{
+			Method:  strings.ToUpper(handler.Method()),
+			Path:    routePath(prefix, handler.Path()),
+			Handler: name,
+			group:   group,
+		}
+
+		if err := validateHandler(handler); err != nil {
+			errs = append(errs, &RegistrationError{
+				Handler: name,
+				Route:   route.Method + " " + route.Path,
+				Reason:  err,
+			})
+			continue
 		}
 
-		handler.Register(router, validator, middlewares...)
-		fmt.Printf("Registered %s %s\n", handler.Method(), path)
+		handlers = append(handlers, handler)
+		candidates = append(candidates, route)
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	// Check every route before registering any of them
+	// so a conflicting handler is never partially registered
+	if err := routes.check(candidates); err != nil {
+		return err
+	}
+
+	routes.add(candidates)
+
+	// Middlewares built with Secure add their scheme to the documented security
+	security := config.security.merge(middlewaresSecurity(middlewares))
+
+	for _, handler := range handlers {
+		handler.Register(router, config, middlewares...)
 		if apiSchema != nil {
-			apiSchema.RegisterHandler(path, handler)
+			apiSchema.registerHandler(prefix, handler, security.merge(middlewaresSecurity(handler.Middlewares())))
+		}
+	}
+
+	return nil
+}
+
+// validatedHandler is implemented by handlers that can check their own
+// configuration before being registered
+type validatedHandler interface {
+	validate() error
+}
+
+// validateHandler checks the handler path and configuration
+func validateHandler(handler Handler) error {
+	if err := validatePath(handler.Path()); err != nil {
+		return err
+	}
+
+	if h, ok := handler.(validatedHandler); ok {
+		return h.validate()
+	}
+
+	return nil
+}
+
+// optionalParam matches a parameter made optional with ?, e.g. :id? or :id<int>?,
+// and the delimiter that follows it
+var optionalParam = regexp.MustCompile(`:[A-Za-z0-9_]+(<[^>]*>)?\?([/.\-]|$)`)
+
+// validatePath checks that a path can be served by the router
+func validatePath(p string) error {
+	for _, segment := range strings.Split(p, "/") {
+		if segment == ":" || segment == ":?" {
+			return fmt.Errorf("%w %q: parameters must have a name", ErrInvalidPath, p)
 		}
 	}
+
+	// ? is only allowed after a parameter, anywhere else it starts a query
+	withoutOptional := optionalParam.ReplaceAllString(p, "$2")
+	if strings.ContainsAny(withoutOptional, " \t\n?#") {
+		return fmt.Errorf("%w %q: must not contain spaces, query or fragment", ErrInvalidPath, p)
+	}
+
+	return nil
+}
+
+// isNilPointer reports whether v is a typed nil pointer
+func isNilPointer(v any) bool {
+	value := reflect.ValueOf(v)
+	return value.Kind() == reflect.Ptr && value.IsNil()
+}
+
+// routePath returns the full path of a route as served by the router
+func routePath(prefix, handlerPath string) string {
+	return path.Join("/", prefix, handlerPath)
 }
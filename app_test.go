@@ -0,0 +1,66 @@
+package fast
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestValidatePath(t *testing.T) {
+	tests := []struct {
+		path    string
+		wantErr bool
+	}{
+		{path: "/users"},
+		{path: "/users/:id"},
+		{path: "/users/:id?"},
+		{path: "/users/:id<int>?"},
+		{path: "/b/:id<int>?/*"},
+		{path: "/files/:name.:ext?"},
+		{path: "/users?page=1", wantErr: true},
+		{path: "/users/:id?x", wantErr: true},
+		{path: "/users/?", wantErr: true},
+		{path: "/users/:", wantErr: true},
+		{path: "/users/:?", wantErr: true},
+		{path: "/users#top", wantErr: true},
+		{path: "/my users", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			err := validatePath(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validatePath(%q) = %v, want error %v", tt.path, err, tt.wantErr)
+			}
+
+			if err != nil && !errors.Is(err, ErrInvalidPath) {
+				t.Errorf("error %v does not match ErrInvalidPath", err)
+			}
+		})
+	}
+}
+
+type optionalParamHandler struct{}
+
+func (optionalParamHandler) HandleGet() Handler {
+	return Endpoint[In, Out]().
+		Path("/b/:id<int>?/*").
+		Handle(func(c *Context, in In) (Out, error) {
+			return Out(c.Params("id")), nil
+		})
+}
+
+func TestRegisterOptionalParam(t *testing.T) {
+	app := newTestApp(t)
+	if err := app.Register("/p", optionalParamHandler{}); err != nil {
+		t.Fatal(err)
+	}
+
+	for target, want := range map[string]string{"/p/b/5/x": `"5"`, "/p/b": `""`} {
+		status, body := send(t, app, httptest.NewRequest(http.MethodGet, target, nil))
+		if status != http.StatusOK || body != want {
+			t.Errorf("GET %s = %d %s, want 200 %s", target, status, body, want)
+		}
+	}
+}
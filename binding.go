@@ -0,0 +1,532 @@
+package fast
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/esequiel378/fast/internal/validator"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Binding sources supported by the input binder.
+// Each source is read from the struct tag with the same name, e.g.
+//
+//	type In struct {
+//		ID      int    `path:"id"`
+//		Tenant  string `header:"X-Tenant"`
+//		Session string `cookie:"session"`
+//		Page    int    `query:"page"`
+//		Name    string `json:"name"`
+//	}
+const (
+	sourcePath   = "path"
+	sourceHeader = "header"
+	sourceCookie = "cookie"
+	sourceQuery  = "query"
+)
+
+// bindingSources lists the explicit binding tags in lookup order
+var bindingSources = []string{sourcePath, sourceHeader, sourceCookie, sourceQuery}
+
+// textUnmarshalerType is used to detect types that know how to parse themselves
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// setter parses a single raw value into the given value
+type setter func(v reflect.Value, raw string) error
+
+// fieldPlan is the precomputed binding of a single struct field
+type fieldPlan struct {
+	index    int
+	source   string
+	name     string
+	explicit bool
+	slice    bool
+	set      setter
+}
+
+// bindingPlan is the binding and validation strategy for an input type.
+// It is built once when the endpoint is created, so each request only
+// runs the precomputed field setters and validation checks instead of
+// walking the type again. The body is still decoded by Fiber's BodyParser.
+type bindingPlan struct {
+	isStruct bool
+	validate bool
+	// checks are the compiled `validate` rules, nil when the input
+	// uses rules that only the validator can run
+	checks *compiledChecks
+	// structs are the struct types reachable from the input, used to find
+	// the struct level validations registered for it
+	structs []reflect.Type
+	// body is set when the input has fields read from the request body
+	body   bool
+	fields []fieldPlan
+	// query maps query keys to fields, implicit keys are the lower-cased
+	// field names
+	query         map[string]int
+	implicitQuery map[string]int
+	hasSliceQuery bool
+	err           error
+}
+
+// newBindingPlan builds the binding plan for the given input type
+func newBindingPlan(t reflect.Type) *bindingPlan {
+	plan := &bindingPlan{
+		query:         make(map[string]int),
+		implicitQuery: make(map[string]int),
+	}
+
+	if t == nil || t.Kind() != reflect.Struct {
+		plan.body = t != nil
+		return plan
+	}
+
+	plan.isStruct = true
+	plan.validate = hasValidationRules(t, make(map[reflect.Type]bool))
+	plan.structs = structTypes(t, make(map[reflect.Type]bool))
+	if plan.validate {
+		plan.checks = compileChecks(t)
+	}
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+
+		source, name, explicit := fieldBinding(field)
+
+		// Fields without a binding tag are decoded from the body,
+		// including the ones of embedded structs
+		if !explicit && (field.IsExported() || field.Anonymous) && field.Tag.Get("json") != "-" {
+			plan.body = true
+		}
+
+		// Skip unexported fields
+		if !field.IsExported() {
+			continue
+		}
+		if !explicit && !isBindable(field.Type) {
+			continue
+		}
+
+		fieldType := field.Type
+		isSlice := fieldType.Kind() == reflect.Slice && !reflect.PointerTo(fieldType).Implements(textUnmarshalerType)
+		if isSlice {
+			fieldType = fieldType.Elem()
+		}
+
+		set, err := newSetter(fieldType)
+		if err != nil {
+			plan.err = fmt.Errorf("field %s: %w", field.Name, err)
+			return plan
+		}
+
+		plan.fields = append(plan.fields, fieldPlan{
+			index:    i,
+			source:   source,
+			name:     name,
+			explicit: explicit,
+			slice:    isSlice,
+			set:      set,
+		})
+
+		if source != sourceQuery {
+			continue
+		}
+
+		if explicit {
+			plan.query[name] = len(plan.fields) - 1
+		} else {
+			plan.implicitQuery[strings.ToLower(name)] = len(plan.fields) - 1
+		}
+
+		plan.hasSliceQuery = plan.hasSliceQuery || isSlice
+	}
+
+	return plan
+}
+
+// bind populates input from every request source declared by its struct tags.
+// The body is decoded first, then query, path, header and cookie values are
+// applied on top of it. Fields with a binding tag are only read from their
+// source, never from the body, and inputs without body fields ignore the body.
+// Fields without an explicit binding tag are read from the query string
+// only when the request has no body, matching the previous parser behavior.
+func (p *bindingPlan) bind(c *fiber.Ctx, input any) []validator.Error {
+	var errs []validator.Error
+
+	hasBody := p.body && len(c.BodyRaw()) > 0
+	if hasBody {
+		if err := c.BodyParser(input); err != nil {
+			errs = append(errs, validator.Error{
+				Message: err.Error(),
+			})
+		}
+	}
+
+	if !p.isStruct || len(p.fields) == 0 {
+		return errs
+	}
+
+	value := reflect.ValueOf(input).Elem()
+
+	// The body parser does not know about binding tags, so it may have set
+	// fields that must only come from the URL, headers or cookies
+	if hasBody {
+		for _, field := range p.fields {
+			if field.explicit {
+				value.Field(field.index).SetZero()
+			}
+		}
+	}
+
+	errs = p.bindQuery(c, value, hasBody, errs)
+
+	for _, field := range p.fields {
+		var raw string
+
+		switch field.source {
+		case sourcePath:
+			raw = c.Params(field.name)
+		case sourceCookie:
+			raw = c.Cookies(field.name)
+		case sourceHeader:
+			if field.slice {
+				var raws []string
+				for _, raw := range c.Request().Header.PeekAll(field.name) {
+					raws = append(raws, string(raw))
+				}
+				errs = field.setAll(value, raws, errs)
+				continue
+			}
+			raw = c.Get(field.name)
+		default:
+			continue
+		}
+
+		if raw == "" {
+			continue
+		}
+
+		if err := field.set(value.Field(field.index), raw); err != nil {
+			errs = append(errs, field.error(err))
+		}
+	}
+
+	return errs
+}
+
+// decode binds and validates the input, returning an inputError on failure
+// or the error of the ContextValidator of the input.
+// The field errors of the `validate` tags and of the ContextValidator
+// are reported together.
+func (p *bindingPlan) decode(c *Context, input any) error {
+	if errs := p.bind(c.Ctx, input); len(errs) > 0 {
+		return inputError{
+			status: fiber.StatusBadRequest,
+			errors: errs,
+		}
+	}
+
+	var errs []validator.Error
+
+	if p.isStruct && c.config.validates(p.validate, p.structs) && !p.passes(c.config, input) {
+		if err := c.config.validator.ValidateStruct(input); err != nil {
+			errs = c.config.validator.Translate(err, c.Locales()...)
+		}
+	}
+
+	if hook, ok := input.(ContextValidator); ok {
+		if err := hook.Validate(c); err != nil {
+			var fieldErrs FieldErrors
+			switch {
+			case errors.As(err, &fieldErrs):
+				errs = append(errs, fieldErrs...)
+			case len(errs) == 0:
+				return err
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return inputError{
+			status: fiber.StatusUnprocessableEntity,
+			errors: errs,
+		}
+	}
+
+	return nil
+}
+
+// passes reports whether the input passes the compiled checks.
+// Inputs that fail them, or that can not use them with the validator of
+// the app, go through the validator, which reports every failing rule.
+func (p *bindingPlan) passes(cfg *config, input any) bool {
+	if p.checks == nil || cfg.validateAll {
+		return false
+	}
+
+	for _, t := range p.structs {
+		if cfg.structValidations[t] {
+			return false
+		}
+	}
+
+	for tag := range cfg.customValidations {
+		if p.checks.tags[tag] {
+			return false
+		}
+	}
+
+	vars, ok := cfg.validator.(varValidator)
+	if p.checks.delegates && !ok {
+		return false
+	}
+
+	return p.checks.run == nil || p.checks.run(reflect.ValueOf(input).Elem(), vars)
+}
+
+// bindQuery applies the query string values in a single pass over the arguments.
+// Slice values are collected first and assigned once all arguments were visited.
+func (p *bindingPlan) bindQuery(c *fiber.Ctx, value reflect.Value, hasBody bool, errs []validator.Error) []validator.Error {
+	if len(p.query) == 0 && (hasBody || len(p.implicitQuery) == 0) {
+		return errs
+	}
+
+	var pending [][]string
+	if p.hasSliceQuery {
+		pending = make([][]string, len(p.fields))
+	}
+
+	c.Context().QueryArgs().VisitAll(func(key, raw []byte) {
+		idx, ok := p.query[string(key)]
+		if !ok && !hasBody {
+			idx, ok = p.implicitQuery[strings.ToLower(string(key))]
+		}
+
+		if !ok {
+			return
+		}
+
+		field := p.fields[idx]
+		if field.slice {
+			pending[idx] = append(pending[idx], string(raw))
+			return
+		}
+
+		if err := field.set(value.Field(field.index), string(raw)); err != nil {
+			errs = append(errs, field.error(err))
+		}
+	})
+
+	for idx, raws := range pending {
+		errs = p.fields[idx].setAll(value, raws, errs)
+	}
+
+	return errs
+}
+
+// setAll assigns every raw value to a slice field
+func (f fieldPlan) setAll(value reflect.Value, raws []string, errs []validator.Error) []validator.Error {
+	if len(raws) == 0 {
+		return errs
+	}
+
+	target := value.Field(f.index)
+	slice := reflect.MakeSlice(target.Type(), len(raws), len(raws))
+
+	for idx, raw := range raws {
+		if err := f.set(slice.Index(idx), raw); err != nil {
+			return append(errs, f.error(err))
+		}
+	}
+
+	target.Set(slice)
+
+	return errs
+}
+
+// error wraps a binding error with the field name
+func (f fieldPlan) error(err error) validator.Error {
+	return validator.Error{
+		Field:   f.name,
+		Message: err.Error(),
+	}
+}
+
+// fieldBinding returns the source and the name a field is bound from.
+// Fields without a binding tag fall back to the query string using the
+// field name, matched case-insensitively as Fiber's query parser does.
+// Their json name is only used for the body.
+func fieldBinding(field reflect.StructField) (source, name string, explicit bool) {
+	for _, source := range bindingSources {
+		tag, ok := field.Tag.Lookup(source)
+		if !ok {
+			continue
+		}
+
+		name := strings.SplitN(tag, ",", 2)[0]
+		if name == "" || name == "-" {
+			name = field.Name
+		}
+
+		return source, name, true
+	}
+
+	return sourceQuery, field.Name, false
+}
+
+// isBindable reports whether a type can be parsed from a raw string value
+func isBindable(t reflect.Type) bool {
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return true
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice:
+		return isBindable(t.Elem())
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// newSetter returns the setter that parses raw values into the given type
+func newSetter(t reflect.Type) (setter, error) {
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return func(v reflect.Value, raw string) error {
+			if err := v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw)); err != nil {
+				return fmt.Errorf("invalid value %q: %w", raw, err)
+			}
+			return nil
+		}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		set, err := newSetter(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+
+		return func(v reflect.Value, raw string) error {
+			elem := reflect.New(t.Elem())
+			if err := set(elem.Elem(), raw); err != nil {
+				return err
+			}
+			v.Set(elem)
+			return nil
+		}, nil
+
+	case reflect.String:
+		return func(v reflect.Value, raw string) error {
+			v.SetString(raw)
+			return nil
+		}, nil
+
+	case reflect.Bool:
+		return func(v reflect.Value, raw string) error {
+			parsed, err := strconv.ParseBool(raw)
+			if err != nil {
+				return fmt.Errorf("invalid value %q: expected a boolean", raw)
+			}
+			v.SetBool(parsed)
+			return nil
+		}, nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		bits := t.Bits()
+		return func(v reflect.Value, raw string) error {
+			parsed, err := strconv.ParseInt(raw, 10, bits)
+			if err != nil {
+				return fmt.Errorf("invalid value %q: expected an integer", raw)
+			}
+			v.SetInt(parsed)
+			return nil
+		}, nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		bits := t.Bits()
+		return func(v reflect.Value, raw string) error {
+			parsed, err := strconv.ParseUint(raw, 10, bits)
+			if err != nil {
+				return fmt.Errorf("invalid value %q: expected a positive integer", raw)
+			}
+			v.SetUint(parsed)
+			return nil
+		}, nil
+
+	case reflect.Float32, reflect.Float64:
+		bits := t.Bits()
+		return func(v reflect.Value, raw string) error {
+			parsed, err := strconv.ParseFloat(raw, bits)
+			if err != nil {
+				return fmt.Errorf("invalid value %q: expected a number", raw)
+			}
+			v.SetFloat(parsed)
+			return nil
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported binding type %s", t)
+	}
+}
+
+// hasValidationRules reports whether a type declares any `validate` tag,
+// so inputs and outputs without rules can skip the validator entirely
+func hasValidationRules(t reflect.Type, visited map[reflect.Type]bool) bool {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return hasValidationRules(t.Elem(), visited)
+	case reflect.Struct:
+	default:
+		return false
+	}
+
+	if visited[t] {
+		return false
+	}
+	visited[t] = true
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+
+		if _, ok := field.Tag.Lookup("validate"); ok {
+			return true
+		}
+
+		if hasValidationRules(field.Type, visited) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// structTypes returns the struct types reachable from a type
+func structTypes(t reflect.Type, visited map[reflect.Type]bool) []reflect.Type {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return structTypes(t.Elem(), visited)
+	case reflect.Struct:
+	default:
+		return nil
+	}
+
+	if visited[t] {
+		return nil
+	}
+	visited[t] = true
+
+	types := []reflect.Type{t}
+	for i := range t.NumField() {
+		types = append(types, structTypes(t.Field(i).Type, visited)...)
+	}
+
+	return types
+}
@@ -0,0 +1,203 @@
+package fast
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/esequiel378/fast/internal/validator"
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+type benchSmallIn struct {
+	Name  string `query:"name" validate:"required"`
+	Page  int    `query:"page" validate:"gte=0"`
+	Limit int    `query:"limit" validate:"lte=100"`
+}
+
+type benchAddress struct {
+	Street string `json:"street" validate:"required"`
+	City   string `json:"city" validate:"required"`
+	Zip    string `json:"zip" validate:"len=5"`
+}
+
+type benchNestedIn struct {
+	Name    string       `json:"name" validate:"required"`
+	Email   string       `json:"email" validate:"required,email"`
+	Address benchAddress `json:"address"`
+	Billing struct {
+		Address benchAddress `json:"address"`
+		Plan    string       `json:"plan" validate:"oneof=free pro"`
+	} `json:"billing"`
+}
+
+type benchItem struct {
+	SKU      string `json:"sku" validate:"required"`
+	Quantity int    `json:"quantity" validate:"gte=1"`
+}
+
+type benchSliceIn struct {
+	IDs   []int       `query:"ids" validate:"max=100"`
+	Tags  []string    `json:"tags" validate:"dive,required"`
+	Items []benchItem `json:"items" validate:"dive"`
+}
+
+// newBenchCtx builds a reusable request context for the given request
+func newBenchCtx(b *testing.B, app *fiber.App, method, uri, body string) *fiber.Ctx {
+	b.Helper()
+
+	fctx := &fasthttp.RequestCtx{}
+	fctx.Request.Header.SetMethod(method)
+	fctx.Request.SetRequestURI(uri)
+
+	if body != "" {
+		fctx.Request.Header.SetContentType(fiber.MIMEApplicationJSON)
+		fctx.Request.SetBodyString(body)
+	}
+
+	return app.AcquireCtx(fctx)
+}
+
+// benchmarkLegacyBinding runs the per-request parser and validator used before binding plans
+func benchmarkLegacyBinding[I any](b *testing.B, method, uri, body string) {
+	app := fiber.New()
+	c := newBenchCtx(b, app, method, uri, body)
+	defer app.ReleaseCtx(c)
+
+	v, err := validator.NewValidatorV10()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		var input I
+
+		parser := c.QueryParser
+		if len(c.BodyRaw()) > 0 {
+			parser = c.BodyParser
+		}
+
+		if err := parser(&input); err != nil {
+			b.Fatal(err)
+		}
+
+		if err := v.ValidateStruct(&input); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// benchmarkPlanBinding runs the precompiled binding plan and validation checks
+func benchmarkPlanBinding[I any](b *testing.B, method, uri, body string) {
+	app := fiber.New()
+	c := newBenchCtx(b, app, method, uri, body)
+	defer app.ReleaseCtx(c)
+
+	v, err := validator.NewValidatorV10()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	cfg := &config{validator: v}
+
+	plan := newBindingPlan(reflect.TypeFor[I]())
+	if plan.err != nil {
+		b.Fatal(plan.err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		var input I
+
+		if errs := plan.bind(c, &input); len(errs) > 0 {
+			b.Fatal(errs)
+		}
+
+		if plan.validate && !plan.passes(cfg, &input) {
+			if err := v.ValidateStruct(&input); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+const (
+	benchSmallURI   = "/?name=fast&page=2&limit=50"
+	benchNestedURI  = "/"
+	benchNestedBody = `{
+		"name": "Fast",
+		"email": "fast@example.com",
+		"address": {"street": "Main St", "city": "Springfield", "zip": "12345"},
+		"billing": {
+			"address": {"street": "Main St", "city": "Springfield", "zip": "12345"},
+			"plan": "pro"
+		}
+	}`
+)
+
+// benchSliceRequest returns a request with many repeated query values and body items
+func benchSliceRequest() (uri, body string) {
+	var (
+		query strings.Builder
+		items []string
+		tags  []string
+	)
+
+	query.WriteString("/?")
+	for i := range 50 {
+		if i > 0 {
+			query.WriteString("&")
+		}
+		query.WriteString("ids=" + strconv.Itoa(i))
+		tags = append(tags, `"tag-`+strconv.Itoa(i)+`"`)
+		items = append(items, `{"sku":"SKU-`+strconv.Itoa(i)+`","quantity":`+strconv.Itoa(i+1)+`}`)
+	}
+
+	body = `{"tags":[` + strings.Join(tags, ",") + `],"items":[` + strings.Join(items, ",") + `]}`
+
+	return query.String(), body
+}
+
+func BenchmarkBindingSmall(b *testing.B) {
+	b.Run("legacy", func(b *testing.B) {
+		benchmarkLegacyBinding[benchSmallIn](b, fiber.MethodGet, benchSmallURI, "")
+	})
+	b.Run("plan", func(b *testing.B) {
+		benchmarkPlanBinding[benchSmallIn](b, fiber.MethodGet, benchSmallURI, "")
+	})
+}
+
+func BenchmarkBindingNested(b *testing.B) {
+	b.Run("legacy", func(b *testing.B) {
+		benchmarkLegacyBinding[benchNestedIn](b, fiber.MethodPost, benchNestedURI, benchNestedBody)
+	})
+	b.Run("plan", func(b *testing.B) {
+		benchmarkPlanBinding[benchNestedIn](b, fiber.MethodPost, benchNestedURI, benchNestedBody)
+	})
+}
+
+func BenchmarkBindingSlices(b *testing.B) {
+	uri, body := benchSliceRequest()
+
+	// The legacy path only reads the body when present, so the query ids
+	// are benchmarked separately to keep both paths doing the same work
+	b.Run("legacy/query", func(b *testing.B) {
+		benchmarkLegacyBinding[benchSliceIn](b, fiber.MethodGet, uri, "")
+	})
+	b.Run("plan/query", func(b *testing.B) {
+		benchmarkPlanBinding[benchSliceIn](b, fiber.MethodGet, uri, "")
+	})
+	b.Run("legacy/body", func(b *testing.B) {
+		benchmarkLegacyBinding[benchSliceIn](b, fiber.MethodPost, "/", body)
+	})
+	b.Run("plan/body", func(b *testing.B) {
+		benchmarkPlanBinding[benchSliceIn](b, fiber.MethodPost, "/", body)
+	})
+}
@@ -0,0 +1,185 @@
+package fast
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type sourcesIn struct {
+	ID      int    `path:"id"`
+	Tenant  string `header:"X-Tenant"`
+	Session string `cookie:"session"`
+	Page    int    `query:"page"`
+	Name    string `json:"name"`
+}
+
+type sourcesHandler struct{}
+
+func (sourcesHandler) HandleUpdate() Handler {
+	return Endpoint[sourcesIn, sourcesIn]().
+		Method(http.MethodPost).
+		Path("/:id").
+		Handle(func(c *Context, in sourcesIn) (sourcesIn, error) {
+			return in, nil
+		})
+}
+
+type requiredHeaderIn struct {
+	Tenant string `header:"X-Tenant" validate:"required"`
+}
+
+type requiredHeaderHandler struct{}
+
+func (requiredHeaderHandler) HandleCreate() Handler {
+	return Endpoint[requiredHeaderIn, requiredHeaderIn]().
+		Method(http.MethodPost).
+		Handle(func(c *Context, in requiredHeaderIn) (requiredHeaderIn, error) {
+			return in, nil
+		})
+}
+
+func newTestApp(t *testing.T, opts ...func(*App)) App {
+	t.Helper()
+
+	app, err := New(opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return app
+}
+
+// send performs a request and returns the status and the body of the response
+func send(t *testing.T, app App, req *http.Request) (int, string) {
+	t.Helper()
+
+	resp, err := app.server.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return resp.StatusCode, string(body)
+}
+
+func TestBindingSourcePrecedence(t *testing.T) {
+	app := newTestApp(t)
+	app.MustRegister("/items", sourcesHandler{})
+	app.MustRegister("/tenants", requiredHeaderHandler{})
+
+	tests := []struct {
+		name       string
+		target     string
+		body       string
+		header     map[string]string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "every source",
+			target:     "/items/5?page=2",
+			body:       `{"name":"rex"}`,
+			header:     map[string]string{"X-Tenant": "acme", "Cookie": "session=abc"},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"ID":5,"Tenant":"acme","Session":"abc","Page":2,"name":"rex"}`,
+		},
+		{
+			name:       "explicit sources win over the body",
+			target:     "/items/5?page=2",
+			body:       `{"ID":9,"Tenant":"evil","Session":"evil","Page":9,"name":"rex"}`,
+			header:     map[string]string{"X-Tenant": "acme", "Cookie": "session=abc"},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"ID":5,"Tenant":"acme","Session":"abc","Page":2,"name":"rex"}`,
+		},
+		{
+			name:       "explicit sources are never read from the body",
+			target:     "/items/5",
+			body:       `{"Tenant":"evil","Session":"evil","Page":9,"name":"rex"}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"ID":5,"Tenant":"","Session":"","Page":0,"name":"rex"}`,
+		},
+		{
+			name:       "missing required header is not filled from the body",
+			target:     "/tenants",
+			body:       `{"Tenant":"evil"}`,
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "inputs without body fields ignore the body",
+			target:     "/tenants",
+			body:       `not json`,
+			header:     map[string]string{"X-Tenant": "acme"},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"Tenant":"acme"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.target, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			for key, value := range tt.header {
+				req.Header.Set(key, value)
+			}
+
+			status, body := send(t, app, req)
+			if status != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", status, tt.wantStatus, body)
+			}
+
+			if tt.wantBody != "" && body != tt.wantBody {
+				t.Errorf("body = %s, want %s", body, tt.wantBody)
+			}
+		})
+	}
+}
+
+type implicitQueryIn struct {
+	UserID int    `json:"user_id"`
+	Search string `json:"q,omitempty"`
+}
+
+type implicitQueryHandler struct{}
+
+func (implicitQueryHandler) HandleList() Handler {
+	return Endpoint[implicitQueryIn, implicitQueryIn]().
+		Method(http.MethodGet).
+		Handle(func(c *Context, in implicitQueryIn) (implicitQueryIn, error) {
+			return in, nil
+		})
+}
+
+func TestImplicitQueryUsesFieldName(t *testing.T) {
+	app := newTestApp(t)
+	app.MustRegister("/users", implicitQueryHandler{})
+
+	tests := []struct {
+		target   string
+		wantBody string
+	}{
+		{target: "/users?UserID=3", wantBody: `{"user_id":3}`},
+		{target: "/users?userid=3&search=rex", wantBody: `{"user_id":3,"q":"rex"}`},
+		{target: "/users?user_id=3&q=rex", wantBody: `{"user_id":0}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			status, body := send(t, app, httptest.NewRequest(http.MethodGet, tt.target, nil))
+			if status != http.StatusOK {
+				t.Fatalf("status = %d: %s", status, body)
+			}
+
+			if body != tt.wantBody {
+				t.Errorf("body = %s, want %s", body, tt.wantBody)
+			}
+		})
+	}
+}
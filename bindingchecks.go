@@ -0,0 +1,461 @@
+package fast
+
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// check reports whether a value passes the `validate` rules compiled for it.
+// vars validates the rules that are not compiled, see varValidator.
+type check func(v reflect.Value, vars varValidator) bool
+
+// varValidator validates a single value against a `validate` tag.
+// It is implemented by the default validator.
+type varValidator interface {
+	ValidateVar(value any, tag string) error
+}
+
+// compiledChecks are the `validate` rules of an input compiled into checks,
+// so valid inputs never walk the type through the validator. They only tell
+// whether the input is valid, invalid inputs go through the validator to get
+// the translated messages of every failing rule.
+type compiledChecks struct {
+	// run is nil when no field has rules
+	run check
+	// tags are the rule names used by the input, the checks are skipped
+	// when any of them is replaced with WithValidation
+	tags map[string]bool
+	// delegates is set when some rules are run by the varValidator
+	delegates bool
+}
+
+// compiledField is the check of a struct field
+type compiledField struct {
+	index int
+	check check
+}
+
+// rule is a single rule of a `validate` tag
+type rule struct {
+	name  string
+	param string
+}
+
+var (
+	timeType     = reflect.TypeFor[time.Time]()
+	durationType = reflect.TypeFor[time.Duration]()
+	// oneOfParam splits the values of oneof, single quotes allow spaces
+	oneOfParam = regexp.MustCompile(`'[^']*'|\S+`)
+)
+
+// checkCompiler compiles the `validate` rules of a type the way the
+// validator runs them: pointers are dereferenced, struct fields without
+// rules are validated as a whole and rules after `dive` apply to the items.
+type checkCompiler struct {
+	checks   *compiledChecks
+	visiting map[reflect.Type]bool
+}
+
+// compileChecks compiles the rules of a struct type.
+// It returns nil when any rule can not be compiled.
+func compileChecks(t reflect.Type) *compiledChecks {
+	compiler := checkCompiler{
+		checks:   &compiledChecks{tags: make(map[string]bool)},
+		visiting: make(map[reflect.Type]bool),
+	}
+
+	run, ok := compiler.structCheck(t, false)
+	if !ok {
+		return nil
+	}
+
+	compiler.checks.run = run
+
+	return compiler.checks
+}
+
+// structCheck compiles the fields of a struct.
+// Fields of unexported embedded structs are read only.
+func (cc *checkCompiler) structCheck(t reflect.Type, readOnly bool) (check, bool) {
+	// Recursive types are left to the validator
+	if cc.visiting[t] {
+		return nil, false
+	}
+	cc.visiting[t] = true
+	defer delete(cc.visiting, t)
+
+	var fields []compiledField
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+
+		tag := field.Tag.Get("validate")
+		if tag == "-" {
+			continue
+		}
+
+		fieldCheck, ok := cc.fieldCheck(field.Type, tag, readOnly || !field.IsExported())
+		if !ok {
+			return nil, false
+		}
+
+		if fieldCheck != nil {
+			fields = append(fields, compiledField{index: i, check: fieldCheck})
+		}
+	}
+
+	if len(fields) == 0 {
+		return nil, true
+	}
+
+	return func(v reflect.Value, vars varValidator) bool {
+		for _, field := range fields {
+			if !field.check(v.Field(field.index), vars) {
+				return false
+			}
+		}
+		return true
+	}, true
+}
+
+// fieldCheck compiles the tag of a field, delegating it to the
+// varValidator when it uses rules that are not compiled
+func (cc *checkCompiler) fieldCheck(t reflect.Type, tag string, readOnly bool) (check, bool) {
+	rules, ok := parseRules(tag)
+	for _, rule := range rules {
+		cc.checks.tags[rule.name] = true
+	}
+
+	if ok {
+		if compiled, ok := cc.valueCheck(t, rules, readOnly, false); ok {
+			return compiled, true
+		}
+	}
+
+	return cc.delegate(t, tag, rules, readOnly)
+}
+
+// delegate runs a whole tag with the varValidator. Tags comparing fields,
+// values that can hold any type and read only values can not be delegated.
+func (cc *checkCompiler) delegate(t reflect.Type, tag string, rules []rule, readOnly bool) (check, bool) {
+	if readOnly {
+		return nil, false
+	}
+
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Kind() == reflect.Interface || (t.Kind() == reflect.Struct && !t.ConvertibleTo(timeType)) {
+		return nil, false
+	}
+
+	for _, rule := range rules {
+		if strings.Contains(rule.name, "field") ||
+			strings.HasPrefix(rule.name, "required_") ||
+			strings.HasPrefix(rule.name, "excluded_") ||
+			strings.HasPrefix(rule.name, "skip_") {
+			return nil, false
+		}
+	}
+
+	cc.checks.delegates = true
+
+	return func(v reflect.Value, vars varValidator) bool {
+		return vars.ValidateVar(v.Interface(), tag) == nil
+	}, true
+}
+
+// valueCheck compiles the rules of a value of the given type.
+// pointer is set when the value was reached through a pointer.
+func (cc *checkCompiler) valueCheck(t reflect.Type, rules []rule, readOnly, pointer bool) (check, bool) {
+	switch t.Kind() {
+	case reflect.Ptr:
+		elem, ok := cc.valueCheck(t.Elem(), rules, readOnly, true)
+		if !ok {
+			return nil, false
+		}
+
+		// nil pointers fail every rule unless they can be omitted
+		omit := len(rules) == 0 || rules[0].name == "omitempty"
+
+		return func(v reflect.Value, vars varValidator) bool {
+			if v.IsNil() {
+				return omit
+			}
+			return elem == nil || elem(v.Elem(), vars)
+		}, true
+
+	case reflect.Interface:
+		return nil, false
+
+	case reflect.Struct:
+		if t.ConvertibleTo(timeType) {
+			return nil, len(rules) == 0
+		}
+
+		// The validator ignores required on structs, and omitempty
+		// skips the fields of empty structs
+		omit := false
+		switch {
+		case len(rules) == 1 && rules[0].name == "required":
+			rules = nil
+		case len(rules) == 1 && rules[0].name == "omitempty":
+			omit = !pointer
+			rules = nil
+		}
+
+		if len(rules) > 0 {
+			return nil, false
+		}
+
+		fields, ok := cc.structCheck(t, readOnly)
+		if !ok || fields == nil || !omit {
+			return fields, ok
+		}
+
+		return func(v reflect.Value, vars varValidator) bool {
+			return v.IsZero() || fields(v, vars)
+		}, true
+	}
+
+	return cc.rulesCheck(t, rules, readOnly, pointer)
+}
+
+// rulesCheck compiles the rules of a value that is not a struct
+func (cc *checkCompiler) rulesCheck(t reflect.Type, rules []rule, readOnly, pointer bool) (check, bool) {
+	if len(rules) == 0 {
+		return nil, true
+	}
+
+	current, rest := rules[0], rules[1:]
+
+	if current.name == "dive" {
+		return cc.diveCheck(t, rest, readOnly)
+	}
+
+	next, ok := cc.rulesCheck(t, rest, readOnly, pointer)
+	if !ok {
+		return nil, false
+	}
+
+	if current.name == "omitempty" {
+		if next == nil {
+			return nil, true
+		}
+
+		return func(v reflect.Value, vars varValidator) bool {
+			return !hasValue(v, pointer) || next(v, vars)
+		}, true
+	}
+
+	test, ok := ruleTest(t, current, pointer)
+	if !ok {
+		return nil, false
+	}
+
+	return func(v reflect.Value, vars varValidator) bool {
+		return test(v) && (next == nil || next(v, vars))
+	}, true
+}
+
+// diveCheck compiles the rules of the items of a slice, array or map
+func (cc *checkCompiler) diveCheck(t reflect.Type, rules []rule, readOnly bool) (check, bool) {
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+	default:
+		return nil, false
+	}
+
+	item, ok := cc.valueCheck(t.Elem(), rules, readOnly, false)
+	if !ok || item == nil {
+		return nil, ok
+	}
+
+	if t.Kind() == reflect.Map {
+		return func(v reflect.Value, vars varValidator) bool {
+			iter := v.MapRange()
+			for iter.Next() {
+				if !item(iter.Value(), vars) {
+					return false
+				}
+			}
+			return true
+		}, true
+	}
+
+	return func(v reflect.Value, vars varValidator) bool {
+		for idx := range v.Len() {
+			if !item(v.Index(idx), vars) {
+				return false
+			}
+		}
+		return true
+	}, true
+}
+
+// ruleTest returns the test of a single rule for values of the given type
+func ruleTest(t reflect.Type, r rule, pointer bool) (func(reflect.Value) bool, bool) {
+	switch r.name {
+	case "required":
+		return func(v reflect.Value) bool {
+			return hasValue(v, pointer)
+		}, true
+	case "oneof":
+		return oneOfTest(t, r.param)
+	case "len":
+		return boundTest(t, r.param, func(cmp int) bool { return cmp == 0 })
+	case "min", "gte":
+		return boundTest(t, r.param, func(cmp int) bool { return cmp >= 0 })
+	case "max", "lte":
+		return boundTest(t, r.param, func(cmp int) bool { return cmp <= 0 })
+	case "gt":
+		return boundTest(t, r.param, func(cmp int) bool { return cmp > 0 })
+	case "lt":
+		return boundTest(t, r.param, func(cmp int) bool { return cmp < 0 })
+	default:
+		return nil, false
+	}
+}
+
+// boundTest compares the length of strings, slices and maps, or the value
+// of numbers, with the param of a rule
+func boundTest(t reflect.Type, param string, ok func(cmp int) bool) (func(reflect.Value) bool, bool) {
+	switch t.Kind() {
+	case reflect.String:
+		bound, err := strconv.ParseInt(param, 0, 64)
+		if err != nil {
+			return nil, false
+		}
+		return func(v reflect.Value) bool {
+			return ok(compare(int64(utf8.RuneCountInString(v.String())), bound))
+		}, true
+
+	case reflect.Slice, reflect.Array, reflect.Map:
+		bound, err := strconv.ParseInt(param, 0, 64)
+		if err != nil {
+			return nil, false
+		}
+		return func(v reflect.Value) bool {
+			return ok(compare(int64(v.Len()), bound))
+		}, true
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		// Durations also accept params like 1h
+		if t == durationType {
+			return nil, false
+		}
+		bound, err := strconv.ParseInt(param, 0, 64)
+		if err != nil {
+			return nil, false
+		}
+		return func(v reflect.Value) bool {
+			return ok(compare(v.Int(), bound))
+		}, true
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		bound, err := strconv.ParseUint(param, 0, 64)
+		if err != nil {
+			return nil, false
+		}
+		return func(v reflect.Value) bool {
+			return ok(compare(v.Uint(), bound))
+		}, true
+
+	case reflect.Float32, reflect.Float64:
+		bound, err := strconv.ParseFloat(param, t.Bits())
+		if err != nil {
+			return nil, false
+		}
+		return func(v reflect.Value) bool {
+			return ok(compare(v.Float(), bound))
+		}, true
+
+	default:
+		return nil, false
+	}
+}
+
+// oneOfTest checks that strings and integers are one of the values of the param
+func oneOfTest(t reflect.Type, param string) (func(reflect.Value) bool, bool) {
+	values := make(map[string]bool)
+	for _, value := range oneOfParam.FindAllString(param, -1) {
+		values[strings.ReplaceAll(value, "'", "")] = true
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return func(v reflect.Value) bool {
+			return values[v.String()]
+		}, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(v reflect.Value) bool {
+			return values[strconv.FormatInt(v.Int(), 10)]
+		}, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return func(v reflect.Value) bool {
+			return values[strconv.FormatUint(v.Uint(), 10)]
+		}, true
+	default:
+		return nil, false
+	}
+}
+
+// compare returns -1, 0 or 1 when a is lower, equal or greater than b
+func compare[T int64 | uint64 | float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// hasValue reports whether a value is set, like the required rule:
+// nilable values must not be nil and values reached through a pointer
+// are always set
+func hasValue(v reflect.Value, pointer bool) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Ptr, reflect.Interface, reflect.Chan, reflect.Func:
+		return !v.IsNil()
+	default:
+		return pointer || !v.IsZero()
+	}
+}
+
+// parseRules splits a `validate` tag into its rules.
+// It reports false when the tag uses alternatives or map keys,
+// which are only run by the validator.
+func parseRules(tag string) ([]rule, bool) {
+	if tag == "" {
+		return nil, true
+	}
+
+	parts := strings.Split(tag, ",")
+	rules := make([]rule, 0, len(parts))
+	ok := true
+
+	for _, part := range parts {
+		for _, alternative := range strings.Split(part, "|") {
+			name, param, _ := strings.Cut(alternative, "=")
+			param = strings.ReplaceAll(strings.ReplaceAll(param, "0x2C", ","), "0x7C", "|")
+			rules = append(rules, rule{name: name, param: param})
+		}
+
+		if strings.Contains(part, "|") || part == "keys" || part == "endkeys" {
+			ok = false
+		}
+	}
+
+	return rules, ok
+}
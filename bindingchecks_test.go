@@ -0,0 +1,234 @@
+package fast
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/esequiel378/fast/internal/validator"
+	v10 "github.com/go-playground/validator/v10"
+)
+
+type checksAddress struct {
+	City string `json:"city" validate:"required"`
+	Zip  string `json:"zip" validate:"len=5"`
+}
+
+type checksEmbedded struct {
+	Code string `validate:"min=2"`
+}
+
+type checksContact struct {
+	Email string `validate:"email"`
+}
+
+type checksIn struct {
+	Name    string            `validate:"required,min=2,max=5"`
+	Age     int               `validate:"gte=18,lt=130"`
+	Count   uint8             `validate:"omitempty,gt=2"`
+	Ratio   float32           `validate:"lte=1.5"`
+	Plan    string            `validate:"oneof='free plan' pro"`
+	Level   int               `validate:"oneof=1 2 3"`
+	Limit   *int              `validate:"omitempty,min=3"`
+	Owner   *string           `validate:"required"`
+	Tags    []string          `validate:"max=2,dive,required"`
+	IDs     []int             `validate:"required"`
+	Labels  map[string]string `validate:"dive,min=1"`
+	Home    checksAddress
+	Work    *checksAddress `validate:"required"`
+	Backup  *checksAddress
+	Extra   checksAddress   `validate:"omitempty"`
+	Spare   *checksAddress  `validate:"omitempty"`
+	History []checksAddress `validate:"dive"`
+	checksEmbedded
+}
+
+func validChecksIn() checksIn {
+	owner := "me"
+
+	return checksIn{
+		Name:           "rex",
+		Age:            30,
+		Ratio:          1.5,
+		Plan:           "free plan",
+		Level:          2,
+		Owner:          &owner,
+		Tags:           []string{"a", "b"},
+		IDs:            []int{},
+		Home:           checksAddress{City: "Paris", Zip: "75001"},
+		Work:           &checksAddress{City: "Lyon", Zip: "69001"},
+		checksEmbedded: checksEmbedded{Code: "fr"},
+	}
+}
+
+func TestCompiledChecksMatchValidator(t *testing.T) {
+	v, err := validator.NewValidatorV10()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checks := compileChecks(reflect.TypeFor[checksIn]())
+	if checks == nil {
+		t.Fatal("checks were not compiled")
+	}
+
+	zero, three := 0, 3
+
+	tests := []struct {
+		name   string
+		mutate func(in *checksIn)
+	}{
+		{name: "valid", mutate: func(in *checksIn) {}},
+		{name: "missing name", mutate: func(in *checksIn) { in.Name = "" }},
+		{name: "name counts runes", mutate: func(in *checksIn) { in.Name = "ééééé" }},
+		{name: "name too long", mutate: func(in *checksIn) { in.Name = "rexrex" }},
+		{name: "age below", mutate: func(in *checksIn) { in.Age = 17 }},
+		{name: "age at exclusive bound", mutate: func(in *checksIn) { in.Age = 130 }},
+		{name: "count set and low", mutate: func(in *checksIn) { in.Count = 2 }},
+		{name: "count set", mutate: func(in *checksIn) { in.Count = 3 }},
+		{name: "ratio above", mutate: func(in *checksIn) { in.Ratio = 1.6 }},
+		{name: "plan not listed", mutate: func(in *checksIn) { in.Plan = "free" }},
+		{name: "plan listed", mutate: func(in *checksIn) { in.Plan = "pro" }},
+		{name: "level not listed", mutate: func(in *checksIn) { in.Level = 4 }},
+		{name: "limit below", mutate: func(in *checksIn) { in.Limit = &zero }},
+		{name: "limit set", mutate: func(in *checksIn) { in.Limit = &three }},
+		{name: "nil owner", mutate: func(in *checksIn) { in.Owner = nil }},
+		{name: "too many tags", mutate: func(in *checksIn) { in.Tags = []string{"a", "b", "c"} }},
+		{name: "empty tag", mutate: func(in *checksIn) { in.Tags = []string{"a", ""} }},
+		{name: "nil ids", mutate: func(in *checksIn) { in.IDs = nil }},
+		{name: "empty label", mutate: func(in *checksIn) { in.Labels = map[string]string{"a": ""} }},
+		{name: "label set", mutate: func(in *checksIn) { in.Labels = map[string]string{"a": "b"} }},
+		{name: "invalid nested", mutate: func(in *checksIn) { in.Home.Zip = "1" }},
+		{name: "nil required nested", mutate: func(in *checksIn) { in.Work = nil }},
+		{name: "invalid pointer nested", mutate: func(in *checksIn) { in.Work.City = "" }},
+		{name: "invalid optional nested", mutate: func(in *checksIn) { in.Backup = &checksAddress{} }},
+		{name: "partial omitempty nested", mutate: func(in *checksIn) { in.Extra.City = "Nice" }},
+		{name: "empty omitempty pointer nested", mutate: func(in *checksIn) { in.Spare = &checksAddress{} }},
+		{name: "invalid item", mutate: func(in *checksIn) { in.History = []checksAddress{{City: "Nice"}} }},
+		{name: "invalid embedded", mutate: func(in *checksIn) { in.Code = "f" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := validChecksIn()
+			tt.mutate(&in)
+
+			want := v.ValidateStruct(&in) == nil
+			if got := checks.run(reflect.ValueOf(in), v); got != want {
+				t.Errorf("checks = %t, validator = %t", got, want)
+			}
+		})
+	}
+}
+
+func TestCompiledChecksDelegate(t *testing.T) {
+	type In struct {
+		Email  string            `validate:"required,email"`
+		Code   string            `validate:"alpha|numeric"`
+		At     time.Time         `validate:"required"`
+		Labels map[string]string `validate:"dive,keys,min=1,endkeys"`
+	}
+
+	v, err := validator.NewValidatorV10()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checks := compileChecks(reflect.TypeFor[In]())
+	if checks == nil || !checks.delegates {
+		t.Fatal("checks were not compiled with delegated rules")
+	}
+
+	valid := In{Email: "rex@example.com", Code: "abc", At: time.Now()}
+
+	tests := []struct {
+		name   string
+		mutate func(in *In)
+	}{
+		{name: "valid", mutate: func(in *In) {}},
+		{name: "invalid email", mutate: func(in *In) { in.Email = "rex" }},
+		{name: "invalid alternative", mutate: func(in *In) { in.Code = "a1" }},
+		{name: "missing time", mutate: func(in *In) { in.At = time.Time{} }},
+		{name: "invalid key", mutate: func(in *In) { in.Labels = map[string]string{"": "a"} }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := valid
+			tt.mutate(&in)
+
+			want := v.ValidateStruct(&in) == nil
+			if got := checks.run(reflect.ValueOf(in), v); got != want {
+				t.Errorf("checks = %t, validator = %t", got, want)
+			}
+		})
+	}
+}
+
+func TestCompiledChecksFallback(t *testing.T) {
+	type node struct {
+		Name string `validate:"required"`
+		Next *node
+	}
+
+	tests := []struct {
+		name string
+		t    reflect.Type
+	}{
+		{name: "cross field", t: reflect.TypeOf(struct {
+			From int
+			To   int `validate:"gtfield=From"`
+		}{})},
+		{name: "any", t: reflect.TypeOf(struct {
+			Value any `validate:"required"`
+		}{})},
+		{name: "unexported embedded", t: reflect.TypeOf(struct {
+			checksContact
+		}{})},
+		{name: "recursive", t: reflect.TypeFor[node]()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if checks := compileChecks(tt.t); checks != nil {
+				t.Error("checks were compiled")
+			}
+		})
+	}
+}
+
+type overriddenRuleIn struct {
+	Name string `json:"name" validate:"min=1"`
+}
+
+type overriddenRuleHandler struct{}
+
+func (overriddenRuleHandler) HandleCreate() Handler {
+	return Endpoint[overriddenRuleIn, overriddenRuleIn]().
+		Method(http.MethodPost).
+		Handle(func(c *Context, in overriddenRuleIn) (overriddenRuleIn, error) {
+			return in, nil
+		})
+}
+
+func TestCompiledChecksSkipCustomValidations(t *testing.T) {
+	app := newTestApp(t, WithValidation("min", func(fl v10.FieldLevel) bool {
+		return false
+	}, "{0} is never valid"))
+	app.MustRegister("/names", overriddenRuleHandler{})
+
+	req := httptest.NewRequest(http.MethodPost, "/names", strings.NewReader(`{"name":"rex"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	status, body := send(t, app, req)
+	if status != http.StatusUnprocessableEntity {
+		t.Fatalf("status = %d, want %d: %s", status, http.StatusUnprocessableEntity, body)
+	}
+
+	if !strings.Contains(body, "name is never valid") {
+		t.Errorf("body = %s", body)
+	}
+}
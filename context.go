@@ -6,10 +6,57 @@ import (
 
 type Context struct {
 	*fiber.Ctx
+	config *config
+	// next is set when the middleware ran the rest of the chain itself
+	next bool
 }
 
-func newContext(ctx *fiber.Ctx) *Context {
+func newContext(ctx *fiber.Ctx, cfg *config) *Context {
 	return &Context{
-		Ctx: ctx,
+		Ctx:    ctx,
+		config: cfg,
 	}
 }
+
+// Next runs the rest of the chain. Middlewares only need it to run code
+// after the handler, the chain continues on its own when they return nil.
+func (c *Context) Next() error {
+	c.next = true
+	return c.Ctx.Next()
+}
+
+// statusKey is the Locals key of the status set with Context.SetStatus
+type statusKey struct{}
+
+// SetStatus overrides the status code of the successful response of an endpoint.
+// Unlike Status, it is not replaced by the endpoint status when the output is sent.
+// It can be set in a middleware, the handler can still change it.
+func (c *Context) SetStatus(status int) {
+	c.Locals(statusKey{}, status)
+}
+
+// responseStatus returns the status set with SetStatus, or the given default
+func (c *Context) responseStatus(status int) int {
+	if override, ok := c.Locals(statusKey{}).(int); ok {
+		return override
+	}
+
+	return status
+}
+
+// SetLocale overrides the locales used to translate the validation messages
+// of the request, e.g. with a user preference. Set it in a middleware
+// to apply it to the endpoint input.
+func (c *Context) SetLocale(locales ...string) {
+	c.Locals(localeKey{}, locales)
+}
+
+// Locales returns the locales of the request in preference order, either
+// the ones set with SetLocale or the ones sent in the Accept-Language header
+func (c *Context) Locales() []string {
+	if locales, ok := c.Locals(localeKey{}).([]string); ok {
+		return locales
+	}
+
+	return parseAcceptLanguage(c.Get(fiber.HeaderAcceptLanguage))
+}
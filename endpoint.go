@@ -2,6 +2,7 @@ package fast
 
 import (
 	"net/http"
+	"reflect"
 )
 
 type (
@@ -15,7 +16,14 @@ type (
 type EndpointBuilder[I, O any] struct {
 	path        string
 	method      string
+	status      int
+	headers     map[string]string
 	middlewares []func(*Context) error
+	// outputValidation overrides the app policy when set
+	outputValidation *OutputValidationPolicy
+	docs             endpointDocs
+	example          *endpointExample
+	errors           []ErrorResponse
 }
 
 // Endpoint creates a new endpoint builder
@@ -23,6 +31,7 @@ func Endpoint[I, O any]() *EndpointBuilder[I, O] {
 	return &EndpointBuilder[I, O]{
 		path:   "/",
 		method: http.MethodGet,
+		status: http.StatusOK,
 	}
 }
 
@@ -38,6 +47,90 @@ func (b *EndpointBuilder[I, O]) Method(method string) *EndpointBuilder[I, O] {
 	return b
 }
 
+// Status sets the status code of successful responses, defaults to 200.
+// Responses with 204 No Content or 304 Not Modified are sent without body.
+// Handlers can still override it per request with Context.SetStatus.
+func (b *EndpointBuilder[I, O]) Status(status int) *EndpointBuilder[I, O] {
+	b.status = status
+	return b
+}
+
+// Header sets a header on every successful response of the endpoint.
+// Handlers can still override it per request with Context.Set.
+func (b *EndpointBuilder[I, O]) Header(key, value string) *EndpointBuilder[I, O] {
+	if b.headers == nil {
+		b.headers = make(map[string]string)
+	}
+
+	b.headers[key] = value
+	return b
+}
+
+// OutputValidation overrides the output validation policy of the app for the endpoint.
+// The OnViolation hook of the app is kept when the policy does not set one.
+func (b *EndpointBuilder[I, O]) OutputValidation(policy OutputValidationPolicy) *EndpointBuilder[I, O] {
+	b.outputValidation = &policy
+	return b
+}
+
+// Summary sets the short summary of the endpoint in the OpenAPI schema
+func (b *EndpointBuilder[I, O]) Summary(summary string) *EndpointBuilder[I, O] {
+	b.docs.summary = summary
+	return b
+}
+
+// Description sets the description of the endpoint in the OpenAPI schema, it can use Markdown
+func (b *EndpointBuilder[I, O]) Description(description string) *EndpointBuilder[I, O] {
+	b.docs.description = description
+	return b
+}
+
+// Tags sets the tags of the endpoint in the OpenAPI schema,
+// replacing the ones guessed from the path
+func (b *EndpointBuilder[I, O]) Tags(tags ...string) *EndpointBuilder[I, O] {
+	b.docs.tags = tags
+	return b
+}
+
+// OperationID sets the unique operation id of the endpoint in the OpenAPI schema,
+// used by client generators to name methods
+func (b *EndpointBuilder[I, O]) OperationID(id string) *EndpointBuilder[I, O] {
+	b.docs.operationID = id
+	return b
+}
+
+// Deprecated marks the endpoint as deprecated in the OpenAPI schema
+func (b *EndpointBuilder[I, O]) Deprecated() *EndpointBuilder[I, O] {
+	b.docs.deprecated = true
+	return b
+}
+
+// Example sets an example of the request and response bodies in the OpenAPI schema
+func (b *EndpointBuilder[I, O]) Example(input I, output O) *EndpointBuilder[I, O] {
+	b.example = &endpointExample{
+		input:  input,
+		output: output,
+	}
+	return b
+}
+
+// Errors declares the error responses the endpoint can send, e.g.
+//
+//	Errors(fast.E404[fast.HTTPError](), fast.E409[ConflictBody]())
+//
+// Binding, validation and internal errors are always documented.
+func (b *EndpointBuilder[I, O]) Errors(responses ...ErrorResponse) *EndpointBuilder[I, O] {
+	b.errors = append(b.errors, responses...)
+	return b
+}
+
+// Security documents that the endpoint requires the given security scheme,
+// registered with WithSecurityScheme. Calling it again requires both schemes.
+func (b *EndpointBuilder[I, O]) Security(scheme string, scopes ...string) *EndpointBuilder[I, O] {
+	b.docs.security = b.docs.security.with(scheme, scopes)
+	return b
+}
+
 // Middlewares sets the middlewares of the endpoint
 func (b *EndpointBuilder[I, O]) Middlewares(middlewares ...func(*Context) error) *EndpointBuilder[I, O] {
 	b.middlewares = middlewares
@@ -54,9 +147,17 @@ func (b *EndpointBuilder[I, O]) Handle(fn func(*Context, I) (O, error)) Handler
 	return &endpointHandler[I, O]{
 		path:        b.path,
 		method:      b.method,
+		status:      b.status,
+		headers:     b.headers,
 		handler:     fn,
 		middlewares: b.middlewares,
 		input:       input,
 		output:      output,
+		plan:        newBindingPlan(reflect.TypeFor[I]()),
+
+		outputValidation: b.outputValidation,
+		docs:             b.docs,
+		example:          b.example,
+		errors:           b.errors,
 	}
 }
@@ -0,0 +1,182 @@
+package fast
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"slices"
+
+	"github.com/esequiel378/fast/internal/validator"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ErrorHandler handles the errors returned by endpoints and middlewares.
+// It either writes the response and returns nil, or returns an error,
+// the same one or a new one, to be rendered by the default error renderer.
+//
+//	func(c *fast.Context, err error) error {
+//		if errors.Is(err, ErrMaintenance) {
+//			return c.Status(503).SendString("back soon")
+//		}
+//		return err
+//	}
+type ErrorHandler func(c *Context, err error) error
+
+// ErrorMapper translates an error into another one, usually an HTTP error.
+// It returns nil when it does not handle the error.
+type ErrorMapper func(err error) error
+
+// MapError returns an ErrorMapper that answers with the given status
+// every error matching target with errors.Is, e.g.
+//
+//	fast.MapError(sql.ErrNoRows, http.StatusNotFound)
+func MapError(target error, status int) ErrorMapper {
+	return func(err error) error {
+		if !errors.Is(err, target) {
+			return nil
+		}
+
+		return NewHTTPError(status, http.StatusText(status)).Wrap(err)
+	}
+}
+
+// MapErrorAs returns an ErrorMapper that translates every error matching
+// the type T with errors.As, e.g.
+//
+//	fast.MapErrorAs(func(err *DuplicateEmailError) error {
+//		return fast.ConflictError(err.Error())
+//	})
+func MapErrorAs[T error](fn func(T) error) ErrorMapper {
+	return func(err error) error {
+		var target T
+		if !errors.As(err, &target) {
+			return nil
+		}
+
+		return fn(target)
+	}
+}
+
+// WithErrorHandler sets the handler for the errors returned by endpoints
+// and middlewares, it runs after the error mappers.
+func WithErrorHandler(handler ErrorHandler) func(*App) {
+	return func(a *App) {
+		a.config.errorHandler = handler
+	}
+}
+
+// WithErrorMappers adds error mappers to the app, they are tried in order
+// and the first one returning a non-nil error wins.
+func WithErrorMappers(mappers ...ErrorMapper) func(*App) {
+	return func(a *App) {
+		a.config.errorMappers = append(a.config.errorMappers, mappers...)
+	}
+}
+
+// ErrorHandler overrides the app error handler for the handlers registered on the group
+func (g Group) ErrorHandler(handler ErrorHandler) Group {
+	cfg := *g.config
+	cfg.errorHandler = handler
+	g.config = &cfg
+	return g
+}
+
+// ErrorMappers adds error mappers to the group, they are tried before the app ones
+func (g Group) ErrorMappers(mappers ...ErrorMapper) Group {
+	cfg := *g.config
+	cfg.errorMappers = append(slices.Clone(mappers), g.config.errorMappers...)
+	g.config = &cfg
+	return g
+}
+
+// handleError runs the error mappers and the error handler on an error
+// returned by user code, then renders whatever error is left
+func (cfg *config) handleError(c *fiber.Ctx, err error) error {
+	for _, mapper := range cfg.errorMappers {
+		if mapped := mapper(err); mapped != nil {
+			err = mapped
+			break
+		}
+	}
+
+	if cfg.errorHandler != nil {
+		if err = cfg.errorHandler(newContext(c, cfg), err); err == nil {
+			return nil
+		}
+	}
+
+	return cfg.sendError(c, err)
+}
+
+// sendError writes the response for err, errors unknown to fast are
+// logged and answered with a 500 Internal Server Error
+func (cfg *config) sendError(c *fiber.Ctx, err error) error {
+	if handled, sendErr := cfg.sendKnownError(c, err); handled {
+		return sendErr
+	}
+
+	log.Printf("error in handler %s %s: %s", c.Method(), c.Route().Path, err)
+
+	if cfg.problemDetails {
+		return sendProblem(c, newProblem(c, fiber.StatusInternalServerError, ""))
+	}
+
+	return c.SendStatus(fiber.StatusInternalServerError)
+}
+
+// fiberErrorHandler is the error handler of the fiber app created by New.
+// With problem details it renders the errors raised outside of endpoints,
+// e.g. 404 and 405 for unrouted requests, otherwise Fiber renders them.
+func (cfg *config) fiberErrorHandler(c *fiber.Ctx, err error) error {
+	if !cfg.problemDetails {
+		return fiber.DefaultErrorHandler(c, err)
+	}
+
+	return cfg.sendError(c, err)
+}
+
+// sendKnownError writes the response for the errors produced by fast itself,
+// it reports false when err is not one of them so the caller can handle it.
+func (cfg *config) sendKnownError(c *fiber.Ctx, err error) (bool, error) {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		httpErr = httpErr.withDefaults()
+		for key, value := range httpErr.Headers {
+			c.Set(key, value)
+		}
+		if cfg.problemDetails {
+			return true, sendProblem(c, newHTTPErrorProblem(c, httpErr))
+		}
+		return true, c.Status(httpErr.Status).JSON(httpErr)
+	}
+
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		if cfg.problemDetails {
+			return true, sendProblem(c, newProblem(c, fiberErr.Code, fiberErr.Message))
+		}
+		return true, c.Status(fiberErr.Code).SendString(fiberErr.Message)
+	}
+
+	var inputErr inputError
+	if errors.As(err, &inputErr) {
+		if cfg.problemDetails {
+			return true, sendProblem(c, newValidationProblem(c, inputErr.status, inputErr.errors))
+		}
+		return true, c.Status(inputErr.status).JSON(validator.ValidationErrorSerializer{
+			Errors: inputErr.errors,
+		})
+	}
+
+	var outputErr outputError
+	if errors.As(err, &outputErr) {
+		if cfg.problemDetails {
+			return true, sendProblem(c, newValidationProblem(c, fiber.StatusInternalServerError, outputErr.errors))
+		}
+		return true, c.Status(fiber.StatusInternalServerError).JSON(validator.ValidationErrorSerializer{
+			Errors: outputErr.errors,
+		})
+	}
+
+	return false, nil
+}
@@ -1,37 +1,278 @@
 package fast
 
 import (
+	"errors"
 	"fmt"
+	"maps"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/esequiel378/fast/internal/validator"
 )
 
-// httpError is an error that contains an HTTP status code and a message.
-type httpError struct {
-	status  int
-	message string
+// HTTPError is an error that contains an HTTP status code and a message.
+// It is sent to the client as JSON, e.g.
+//
+//	{"status": 404, "code": "not_found", "message": "user not found"}
+//
+// Tests can assert on it with errors.As:
+//
+//	var httpErr *fast.HTTPError
+//	if errors.As(err, &httpErr) && httpErr.Status == http.StatusNotFound { ... }
+type HTTPError struct {
+	// Status is the HTTP status code of the response, 500 when unset
+	Status int `json:"status"`
+	// Code is a machine-readable error code, defaults to the snake_case status text
+	Code string `json:"code,omitempty"`
+	// Message is the human-readable error message
+	Message string `json:"message"`
+	// Details holds optional structured information about the error
+	Details any `json:"details,omitempty"`
+	// Headers are set on the response, e.g. Retry-After
+	Headers map[string]string `json:"-"`
+	// Err is the underlying cause, it is never sent to the client
+	Err error `json:"-"`
+}
+
+func (e *HTTPError) Error() string {
+	msg := fmt.Sprintf("HTTP %d: %s", e.Status, e.Message)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+
+	return msg
 }
 
-func (e httpError) Error() string {
-	return fmt.Sprintf("HTTP %d: %s", e.status, e.message)
+// Unwrap returns the underlying cause
+func (e *HTTPError) Unwrap() error {
+	return e.Err
 }
 
-func ValidationError(message string) httpError {
-	return httpError{
-		status:  http.StatusUnprocessableEntity,
-		message: message,
+// WithCode returns a copy of the error with the given machine-readable code
+func (e *HTTPError) WithCode(code string) *HTTPError {
+	clone := *e
+	clone.Code = code
+	return &clone
+}
+
+// WithDetails returns a copy of the error with the given structured details
+func (e *HTTPError) WithDetails(details any) *HTTPError {
+	clone := *e
+	clone.Details = details
+	return &clone
+}
+
+// WithHeader returns a copy of the error that sets the given response header
+func (e *HTTPError) WithHeader(key, value string) *HTTPError {
+	clone := *e
+	clone.Headers = make(map[string]string, len(e.Headers)+1)
+	maps.Copy(clone.Headers, e.Headers)
+	clone.Headers[key] = value
+	return &clone
+}
+
+// Wrap returns a copy of the error with the given underlying cause
+func (e *HTTPError) Wrap(err error) *HTTPError {
+	clone := *e
+	clone.Err = err
+	return &clone
+}
+
+// withDefaults returns the error as sent to the client, errors built without
+// NewHTTPError may lack a status or a code
+func (e *HTTPError) withDefaults() *HTTPError {
+	if e.Status >= 100 && e.Code != "" {
+		return e
+	}
+
+	clone := *e
+	if clone.Status < 100 {
+		clone.Status = http.StatusInternalServerError
 	}
+	if clone.Code == "" {
+		clone.Code = statusCode(clone.Status)
+	}
+
+	return &clone
 }
 
-func UnauthorizedError(message string) httpError {
-	return httpError{
-		status:  http.StatusUnauthorized,
-		message: message,
+// NewHTTPError returns an error with the given status and message
+func NewHTTPError(status int, message string) *HTTPError {
+	return &HTTPError{
+		Status:  status,
+		Code:    statusCode(status),
+		Message: message,
 	}
 }
 
-func NewHTTPError(status int, message string) httpError {
-	return httpError{
-		status:  status,
-		message: message,
+// statusCode returns the default machine-readable code of a status, e.g. not_found
+func statusCode(status int) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			return unicode.ToLower(r)
+		case r == ' ' || r == '-':
+			return '_'
+		default:
+			return -1
+		}
+	}, http.StatusText(status))
+}
+
+// withRetryAfter sets the Retry-After header when a delay is given
+func withRetryAfter(err *HTTPError, retryAfter time.Duration) *HTTPError {
+	if retryAfter <= 0 {
+		return err
 	}
+
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+
+	return err.WithHeader("Retry-After", strconv.Itoa(seconds))
+}
+
+// BadRequestError returns a 400 Bad Request error
+func BadRequestError(message string) *HTTPError {
+	return NewHTTPError(http.StatusBadRequest, message)
+}
+
+// UnauthorizedError returns a 401 Unauthorized error
+func UnauthorizedError(message string) *HTTPError {
+	return NewHTTPError(http.StatusUnauthorized, message)
+}
+
+// ForbiddenError returns a 403 Forbidden error
+func ForbiddenError(message string) *HTTPError {
+	return NewHTTPError(http.StatusForbidden, message)
+}
+
+// NotFoundError returns a 404 Not Found error
+func NotFoundError(message string) *HTTPError {
+	return NewHTTPError(http.StatusNotFound, message)
+}
+
+// MethodNotAllowedError returns a 405 Method Not Allowed error
+func MethodNotAllowedError(message string) *HTTPError {
+	return NewHTTPError(http.StatusMethodNotAllowed, message)
+}
+
+// ConflictError returns a 409 Conflict error
+func ConflictError(message string) *HTTPError {
+	return NewHTTPError(http.StatusConflict, message)
+}
+
+// GoneError returns a 410 Gone error
+func GoneError(message string) *HTTPError {
+	return NewHTTPError(http.StatusGone, message)
+}
+
+// PreconditionFailedError returns a 412 Precondition Failed error
+func PreconditionFailedError(message string) *HTTPError {
+	return NewHTTPError(http.StatusPreconditionFailed, message)
+}
+
+// RequestEntityTooLargeError returns a 413 Request Entity Too Large error
+func RequestEntityTooLargeError(message string) *HTTPError {
+	return NewHTTPError(http.StatusRequestEntityTooLarge, message)
+}
+
+// UnsupportedMediaTypeError returns a 415 Unsupported Media Type error
+func UnsupportedMediaTypeError(message string) *HTTPError {
+	return NewHTTPError(http.StatusUnsupportedMediaType, message)
+}
+
+// ValidationError returns a 422 Unprocessable Entity error
+func ValidationError(message string) *HTTPError {
+	return NewHTTPError(http.StatusUnprocessableEntity, message)
+}
+
+// TooManyRequestsError returns a 429 Too Many Requests error.
+// When retryAfter is positive it is sent in the Retry-After header.
+func TooManyRequestsError(message string, retryAfter time.Duration) *HTTPError {
+	return withRetryAfter(NewHTTPError(http.StatusTooManyRequests, message), retryAfter)
+}
+
+// InternalServerError returns a 500 Internal Server Error error.
+// Unlike errors unknown to fast, the message is sent to the client.
+func InternalServerError(message string) *HTTPError {
+	return NewHTTPError(http.StatusInternalServerError, message)
+}
+
+// NotImplementedError returns a 501 Not Implemented error
+func NotImplementedError(message string) *HTTPError {
+	return NewHTTPError(http.StatusNotImplemented, message)
+}
+
+// BadGatewayError returns a 502 Bad Gateway error
+func BadGatewayError(message string) *HTTPError {
+	return NewHTTPError(http.StatusBadGateway, message)
+}
+
+// ServiceUnavailableError returns a 503 Service Unavailable error.
+// When retryAfter is positive it is sent in the Retry-After header.
+func ServiceUnavailableError(message string, retryAfter time.Duration) *HTTPError {
+	return withRetryAfter(NewHTTPError(http.StatusServiceUnavailable, message), retryAfter)
+}
+
+// GatewayTimeoutError returns a 504 Gateway Timeout error
+func GatewayTimeoutError(message string) *HTTPError {
+	return NewHTTPError(http.StatusGatewayTimeout, message)
+}
+
+// inputError is returned when the input of an endpoint or middleware
+// could not be bound or did not pass validation.
+type inputError struct {
+	status int
+	errors []validator.Error
+}
+
+func (e inputError) Error() string {
+	return fmt.Sprintf("HTTP %d: invalid input", e.status)
+}
+
+// outputError is returned when the output of an endpoint did not pass validation
+type outputError struct {
+	errors []validator.Error
+}
+
+func (e outputError) Error() string {
+	return "HTTP 500: invalid output"
+}
+
+// Reasons a handler can not be registered, wrapped by RegistrationError
+var (
+	ErrHandlerNotStruct        = errors.New("handler is not a struct")
+	ErrInvalidHandlerSignature = errors.New("methods starting with `Handle` must take no arguments and return fast.Handler")
+	ErrNilHandler              = errors.New("handle method returned a nil fast.Handler")
+	ErrInvalidPath             = errors.New("invalid path")
+	ErrInvalidInput            = errors.New("invalid input type")
+	ErrInvalidStatus           = errors.New("invalid status code")
+)
+
+// RegistrationError is returned when a handler can not be registered
+type RegistrationError struct {
+	// Handler is the handler type, or the handler type and method when
+	// the error is about a single Handle method, e.g. main.UserHandler.HandleList
+	Handler string
+	// Route is the method and path of the route, if known, e.g. GET /users
+	Route string
+	// Reason is one of the Err* sentinels or a *RouteConflictError
+	Reason error
+}
+
+func (e *RegistrationError) Error() string {
+	msg := "fast: can not register " + e.Handler
+	if e.Route != "" {
+		msg += " (" + e.Route + ")"
+	}
+
+	return msg + ": " + e.Reason.Error()
+}
+
+// Unwrap returns the reason, so errors.Is and errors.As can match it
+func (e *RegistrationError) Unwrap() error {
+	return e.Reason
 }
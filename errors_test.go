@@ -0,0 +1,164 @@
+package fast
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type failingHandler struct {
+	err error
+}
+
+func (h failingHandler) HandleGet() Handler {
+	return Endpoint[struct{}, struct{}]().
+		Method(http.MethodGet).
+		Handle(func(c *Context, in struct{}) (struct{}, error) {
+			return struct{}{}, h.err
+		})
+}
+
+func TestHTTPErrorRendering(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       []func(*App)
+		err        error
+		wantStatus int
+		wantBody   string
+		wantHeader map[string]string
+	}{
+		{
+			name:       "constructor",
+			err:        NotFoundError("user not found").WithDetails(map[string]int{"id": 1}),
+			wantStatus: http.StatusNotFound,
+			wantBody:   `{"status":404,"code":"not_found","message":"user not found","details":{"id":1}}`,
+		},
+		{
+			name:       "wrapped",
+			err:        fmt.Errorf("loading user: %w", ConflictError("taken").WithCode("email_taken")),
+			wantStatus: http.StatusConflict,
+			wantBody:   `{"status":409,"code":"email_taken","message":"taken"}`,
+		},
+		{
+			name:       "literal without status",
+			err:        &HTTPError{Message: "x"},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   `{"status":500,"code":"internal_server_error","message":"x"}`,
+		},
+		{
+			name:       "literal without code",
+			err:        &HTTPError{Status: http.StatusTeapot, Message: "x"},
+			wantStatus: http.StatusTeapot,
+			wantBody:   `{"status":418,"code":"im_a_teapot","message":"x"}`,
+		},
+		{
+			name:       "retry after",
+			err:        TooManyRequestsError("slow down", 1500*time.Millisecond),
+			wantStatus: http.StatusTooManyRequests,
+			wantBody:   `{"status":429,"code":"too_many_requests","message":"slow down"}`,
+			wantHeader: map[string]string{"Retry-After": "2"},
+		},
+		{
+			name:       "problem without status",
+			opts:       []func(*App){WithProblemDetails()},
+			err:        &HTTPError{Message: "x"},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   `{"code":"internal_server_error","detail":"x","instance":"/errors","status":500,"title":"Internal Server Error","type":"about:blank"}`,
+		},
+		{
+			name:       "problem without code",
+			opts:       []func(*App){WithProblemDetails()},
+			err:        &HTTPError{Status: 599, Message: "x"},
+			wantStatus: 599,
+			wantBody:   `{"detail":"x","instance":"/errors","status":599,"type":"about:blank"}`,
+		},
+		{
+			name:       "problem retry after",
+			opts:       []func(*App){WithProblemDetails()},
+			err:        ServiceUnavailableError("maintenance", time.Minute),
+			wantStatus: http.StatusServiceUnavailable,
+			wantHeader: map[string]string{"Retry-After": "60"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestApp(t, tt.opts...)
+			app.MustRegister("/errors", failingHandler{err: tt.err})
+
+			resp, err := app.server.Test(httptest.NewRequest(http.MethodGet, "/errors", nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", resp.StatusCode, tt.wantStatus, body)
+			}
+
+			if tt.wantBody != "" && string(body) != tt.wantBody {
+				t.Errorf("body = %s, want %s", body, tt.wantBody)
+			}
+
+			for key, want := range tt.wantHeader {
+				if got := resp.Header.Get(key); got != want {
+					t.Errorf("%s = %q, want %q", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestHTTPErrorWrapping(t *testing.T) {
+	cause := errors.New("no rows")
+	err := fmt.Errorf("get user: %w", NotFoundError("user not found").Wrap(cause))
+
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		t.Fatal("errors.As did not find the HTTPError")
+	}
+
+	if httpErr.Status != http.StatusNotFound || httpErr.Code != "not_found" {
+		t.Errorf("status = %d, code = %s", httpErr.Status, httpErr.Code)
+	}
+
+	if !errors.Is(err, cause) || httpErr.Unwrap() != cause {
+		t.Error("the cause is not unwrapped")
+	}
+
+	if want := "HTTP 404: user not found: no rows"; httpErr.Error() != want {
+		t.Errorf("Error() = %q, want %q", httpErr.Error(), want)
+	}
+
+	body, err := json.Marshal(httpErr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := `{"status":404,"code":"not_found","message":"user not found"}`; string(body) != want {
+		t.Errorf("json = %s, want %s", body, want)
+	}
+}
+
+func TestHTTPErrorCopies(t *testing.T) {
+	base := TooManyRequestsError("slow down", time.Second)
+	derived := base.WithHeader("X-Limit", "10").WithCode("rate_limited")
+
+	if base.Code != "too_many_requests" || len(base.Headers) != 1 {
+		t.Errorf("base was modified: %+v", base)
+	}
+
+	if derived.Headers["Retry-After"] != "1" || derived.Headers["X-Limit"] != "10" {
+		t.Errorf("headers = %v", derived.Headers)
+	}
+}
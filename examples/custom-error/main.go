@@ -8,7 +8,7 @@ import (
 )
 
 func main() {
-	app, err := fast.New()
+	app, err := fast.New(fast.WithDevelopmentMode())
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -23,6 +23,7 @@ type Handler struct{}
 func (h Handler) HandleGet() fast.Handler {
 	return fast.
 		Endpoint[fast.In, fast.Out]().
+		Errors(fast.E404[fast.HTTPError]()).
 		Handle(func(*fast.Context, fast.In) (fast.Out, error) {
 			return "", fast.NewHTTPError(http.StatusNotFound, "Resource not found")
 		})
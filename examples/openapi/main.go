@@ -7,7 +7,18 @@ import (
 )
 
 func main() {
-	app, err := fast.New(fast.WithExperimentalOpenAPISchema())
+	app, err := fast.New(
+		fast.WithOpenAPI(fast.OpenAPIConfig{
+			Info: fast.OpenAPIInfo{
+				Title:       "Swagger Petstore",
+				Description: "A sample Pet Store server",
+				Version:     "1.0.0",
+				License:     &fast.LicenseObject{Name: "Apache 2.0", URL: "https://www.apache.org/licenses/LICENSE-2.0.html"},
+			},
+			ExternalDocs: &fast.ExternalDocsObject{Description: "Find out more about Swagger", URL: "https://swagger.io"},
+		}),
+		fast.WithSecurityScheme("api_key", fast.APIKeySecurity("header", "api_key")),
+	)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -22,6 +33,7 @@ func main() {
 
 	// Store endpoints
 	app.Group("/store").
+		Security("api_key").
 		MustRegister("/inventory", StoreHandler{}).
 		MustRegister("/order", StoreHandler{}).
 		MustRegister("/order/{orderId}", StoreHandler{})
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"net/http"
+
+	"github.com/esequiel378/fast"
+)
+
+func main() {
+	app, err := fast.New(fast.WithExperimentalOpenAPISchema())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	app.MustRegister("/webhooks", NewWebhookHandler("fast-is-awesome"))
+
+	log.Fatal(app.Listen(":3003"))
+}
+
+type WebhookHandler struct {
+	secret []byte
+}
+
+func NewWebhookHandler(secret string) WebhookHandler {
+	return WebhookHandler{
+		secret: []byte(secret),
+	}
+}
+
+func (h WebhookHandler) HandleReceive() fast.Handler {
+	return fast.
+		RawEndpoint().
+		Method(http.MethodPost).
+		Description("Receives signed events, the X-Signature header must be the hex encoded HMAC-SHA256 of the body").
+		Handle(func(c *fast.Context) error {
+			// BodyRaw returns the body exactly as it was received
+			body := c.BodyRaw()
+
+			mac := hmac.New(sha256.New, h.secret)
+			mac.Write(body)
+			expected := hex.EncodeToString(mac.Sum(nil))
+
+			if !hmac.Equal([]byte(expected), []byte(c.Get("X-Signature"))) {
+				return fast.UnauthorizedError("invalid signature")
+			}
+
+			return c.SendStatus(http.StatusNoContent)
+		})
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/esequiel378/fast"
+)
+
+func main() {
+	app, err := fast.New()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	app.
+		Group("/api", AuthMiddleware()).
+		MustRegister("/greeting", GreetingHandler{})
+
+	log.Fatal(app.Listen(":3003"))
+}
+
+// Principal is the authenticated user of a request
+type Principal struct {
+	Username string
+}
+
+// AuthMiddleware reads the Authorization header and resolves the Principal
+// that made the request, so handlers can access it with fast.Value
+func AuthMiddleware() fast.Middleware {
+	type In struct {
+		Token string `header:"Authorization" validate:"required"`
+	}
+
+	return fast.
+		TypedMiddleware[In, Principal]().
+		Handle(func(_ *fast.Context, in In) (Principal, error) {
+			// Normally, here you would look up the token in a database or an identity provider
+			if in.Token != "Bearer fast-is-awesome" {
+				return Principal{}, fast.UnauthorizedError("invalid token")
+			}
+
+			return Principal{Username: "esequiel"}, nil
+		})
+}
+
+type GreetingHandler struct{}
+
+func (h GreetingHandler) HandleGreeting() fast.Handler {
+	type Out struct {
+		Message string `json:"message" validate:"required"`
+	}
+
+	return fast.
+		Endpoint[fast.In, Out]().
+		Handle(func(c *fast.Context, _ fast.In) (Out, error) {
+			principal := fast.MustValue[Principal](c)
+
+			output := Out{
+				Message: fmt.Sprintf("Hello, %s!", principal.Username),
+			}
+
+			return output, nil
+		})
+}
@@ -3,29 +3,43 @@ package fast
 import (
 	"path"
 
-	"github.com/esequiel378/fast/internal/validator"
 	"github.com/gofiber/fiber/v2"
 )
 
 // Group is a group of routes
 type Group struct {
 	router      fiber.Router
-	validator   validator.Validator
+	config      *config
 	path        string
 	apiSchema   *OpenAPIGenerator
+	routes      *routeTable
+	id          int
 	middlewares []Middleware
 }
 
-// MustRegister registers a handler to the app
-// The handler must be a struct with methods that return a Handler
-func (g Group) MustRegister(prefix string, handler any, middlewares ...Middleware) Group {
-	mustValidateAndRegisterHandler(
+// Register registers a handler to the group
+// The handler must be a struct with methods that return a Handler.
+// It returns an error if the handler is invalid or, with the ErrorOnConflict
+// policy, if any of its routes conflicts with a registered one.
+func (g Group) Register(prefix string, handler any, middlewares ...Middleware) error {
+	return validateAndRegisterHandler(
 		path.Join(g.path, prefix),
 		handler,
 		g.router.Group(prefix),
-		g.validator,
+		g.config,
 		g.apiSchema,
+		g.routes,
+		g.id,
 		append(g.middlewares, middlewares...)...,
 	)
+}
+
+// MustRegister registers a handler to the group
+// The handler must be a struct with methods that return a Handler.
+// It panics if the handler can not be registered.
+func (g Group) MustRegister(prefix string, handler any, middlewares ...Middleware) Group {
+	if err := g.Register(prefix, handler, middlewares...); err != nil {
+		panic(err)
+	}
 	return g
 }
@@ -1,18 +1,16 @@
 package fast
 
 import (
-	"errors"
-	"log"
+	"fmt"
 	"reflect"
 
-	"github.com/esequiel378/fast/internal/validator"
 	"github.com/gofiber/fiber/v2"
 )
 
 // Handler is the interface that links the endpoint to the router
 type Handler interface {
 	// Register registers the endpoint to the given router
-	Register(router fiber.Router, validator validator.Validator, middlewares ...Middleware)
+	Register(router fiber.Router, config *config, middlewares ...Middleware)
 	// Path returns the endpoint path
 	Path() string
 	// Method returns the HTTP method
@@ -29,10 +27,18 @@ type Handler interface {
 type endpointHandler[I, O any] struct {
 	path        string
 	method      string
+	status      int
+	headers     map[string]string
 	handler     func(*Context, I) (O, error)
 	middlewares []func(*Context) error
 	input       I
 	output      O
+	plan        *bindingPlan
+
+	outputValidation *OutputValidationPolicy
+	docs             endpointDocs
+	example          *endpointExample
+	errors           []ErrorResponse
 }
 
 // Path returns the endpoint path
@@ -51,68 +57,128 @@ func (h *endpointHandler[I, O]) Middlewares() []func(*Context) error {
 }
 
 // Register registers the endpoint to the given router
-func (h *endpointHandler[I, O]) Register(r fiber.Router, v validator.Validator, middlewares ...Middleware) {
-	handlers := make([]fiber.Handler, len(h.middlewares)+len(middlewares))
+func (h *endpointHandler[I, O]) Register(r fiber.Router, cfg *config, middlewares ...Middleware) {
+	if h.plan.err != nil {
+		panic(fmt.Sprintf("invalid input for %s %s: %s", h.method, h.path, h.plan.err))
+	}
 
-	for idx, middleware := range append(middlewares, h.middlewares...) {
-		handlers[idx] = func(c *fiber.Ctx) error {
-			err := middleware(newContext(c))
-			var httpErr httpError
-			if errors.As(err, &httpErr) {
-				return c.Status(httpErr.status).SendString(httpErr.message)
-			}
-			return err
-		}
+	handlers := wrapMiddlewares(cfg, append(middlewares, h.middlewares...))
+
+	policy := cfg.outputValidation
+	if h.outputValidation != nil {
+		policy = h.outputValidation.inherit(cfg.outputValidation)
 	}
 
-	var out O
-	shouldValidateOutput := reflect.TypeOf(out).Kind() == reflect.Struct
+	outputType := reflect.TypeFor[O]()
+	shouldValidateOutput := policy.Mode != SkipOutputValidation &&
+		outputType.Kind() == reflect.Struct &&
+		cfg.validates(
+			hasValidationRules(outputType, make(map[reflect.Type]bool)),
+			structTypes(outputType, make(map[reflect.Type]bool)),
+		)
 
 	handlers = append(handlers, func(c *fiber.Ctx) error {
 		var input I
-		parser := c.QueryParser
 
-		if len(c.BodyRaw()) > 0 {
-			parser = c.BodyParser
-		}
+		ctx := newContext(c, cfg)
 
-		if err := parser(&input); err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-        "error": err.Error(),
-      })
+		if err := h.plan.decode(ctx, &input); err != nil {
+			return cfg.handleError(c, err)
 		}
 
-		if err := v.ValidateStruct(&input); err != nil {
-			return c.Status(fiber.StatusUnprocessableEntity).JSON(validator.ValidationErrorSerializer{
-				Errors: v.Translate(err),
-			})
+		output, err := h.handler(ctx, input)
+		if err != nil {
+			sendErr := cfg.handleError(c, err)
+			if cfg.development {
+				checkDeclaredStatus(c, h.errors)
+			}
+			return sendErr
 		}
 
-		output, err := h.handler(newContext(c), input)
-		var httpErr httpError
-		if errors.As(err, &httpErr) {
-			return c.Status(httpErr.status).SendString(httpErr.message)
-		}
+		if shouldValidateOutput && policy.sampled() {
+			if err := cfg.validator.ValidateStruct(&output); err != nil {
+				errs := cfg.validator.Translate(err, ctx.Locales()...)
+				policy.report(ctx, errs)
 
-		if err != nil {
-			log.Printf("error in handler %s %s: %s", h.method, h.path, err)
-			return c.SendStatus(fiber.StatusInternalServerError)
+				if policy.Mode == EnforceOutputValidation {
+					return cfg.sendError(c, outputError{errors: errs})
+				}
+			}
 		}
 
-		if shouldValidateOutput {
-			if err := v.ValidateStruct(&output); err != nil {
-				return c.Status(fiber.StatusInternalServerError).JSON(validator.ValidationErrorSerializer{
-					Errors: v.Translate(err),
-				})
+		// Headers set by the handler take precedence over the endpoint ones
+		for key, value := range h.headers {
+			if len(c.Response().Header.Peek(key)) == 0 {
+				c.Set(key, value)
 			}
 		}
 
-		return c.Status(fiber.StatusOK).JSON(output)
+		status := ctx.responseStatus(h.status)
+
+		if !bodyAllowed(status) {
+			return c.SendStatus(status)
+		}
+
+		return c.Status(status).JSON(output)
 	})
 
 	r.Add(h.method, h.path, handlers...)
 }
 
+// wrapMiddlewares converts the middlewares into fiber handlers that
+// continue the chain when the middleware succeeds, unless it already
+// did by calling Next
+func wrapMiddlewares(cfg *config, middlewares []Middleware) []fiber.Handler {
+	handlers := make([]fiber.Handler, len(middlewares))
+
+	for idx, middleware := range middlewares {
+		handlers[idx] = func(c *fiber.Ctx) error {
+			ctx := newContext(c, cfg)
+			err := middleware(ctx)
+
+			// The rest of the chain already handled its own errors
+			if ctx.next {
+				return err
+			}
+
+			if err != nil {
+				return cfg.handleError(c, err)
+			}
+			return c.Next()
+		}
+	}
+
+	return handlers
+}
+
+// validate reports input types that can not be bound and invalid status codes
+func (h *endpointHandler[I, O]) validate() error {
+	if h.plan.err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidInput, h.plan.err)
+	}
+
+	if h.status < 200 || h.status > 399 {
+		return fmt.Errorf("%w: %d is not a success status", ErrInvalidStatus, h.status)
+	}
+
+	return nil
+}
+
+func (h *endpointHandler[I, O]) meta() endpointMeta {
+	return endpointMeta{
+		docs:    h.docs,
+		example: h.example,
+		status:  h.status,
+		headers: h.headers,
+		errors:  h.errors,
+	}
+}
+
+// bodyAllowed reports whether a response with the given status can have a body
+func bodyAllowed(status int) bool {
+	return status != fiber.StatusNoContent && status != fiber.StatusNotModified
+}
+
 func (h *endpointHandler[I, O]) InputSerializer() any {
 	return h.input
 }
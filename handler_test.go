@@ -0,0 +1,52 @@
+package fast
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type statusHandler struct{}
+
+func (statusHandler) HandleCreate() Handler {
+	return Endpoint[In, Out]().
+		Method(http.MethodPost).
+		Status(http.StatusCreated).
+		Handle(func(c *Context, in In) (Out, error) {
+			if c.Query("accepted") != "" {
+				c.SetStatus(http.StatusAccepted)
+			}
+			return "ok", nil
+		})
+}
+
+func TestSetStatus(t *testing.T) {
+	fromMiddleware := func(c *Context) error {
+		if c.Query("empty") != "" {
+			c.SetStatus(http.StatusNoContent)
+		}
+		return nil
+	}
+
+	app := newTestApp(t)
+	app.Group("/jobs", fromMiddleware).MustRegister("", statusHandler{})
+
+	tests := []struct {
+		target     string
+		wantStatus int
+	}{
+		{target: "/jobs", wantStatus: http.StatusCreated},
+		{target: "/jobs?accepted=1", wantStatus: http.StatusAccepted},
+		{target: "/jobs?empty=1", wantStatus: http.StatusNoContent},
+		{target: "/jobs?empty=1&accepted=1", wantStatus: http.StatusAccepted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			status, body := send(t, app, httptest.NewRequest(http.MethodPost, tt.target, nil))
+			if status != tt.wantStatus {
+				t.Errorf("status = %d, want %d: %s", status, tt.wantStatus, body)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ type Validator interface {
 	// The input should be a pointer to a struct, and it should have exported fields.
 	ValidateStruct(input any) error
 	// Translate translates the error returned by ValidateStruct to a slice of errors.
-	// that can be used to return to the user.
-	Translate(err error) []Error
+	// that can be used to return to the user. Messages are translated to the first
+	// of the given locales that is supported, falling back to English.
+	Translate(err error, locales ...string) []Error
 }
@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"strings"
 
+	"github.com/go-playground/locales"
 	"github.com/go-playground/locales/en"
 	ut "github.com/go-playground/universal-translator"
 	v10 "github.com/go-playground/validator/v10"
@@ -14,9 +15,17 @@ import (
 
 type V10 struct {
 	validate *v10.Validate
-	trans    ut.Translator
+	uni      *ut.UniversalTranslator
+	fallback ut.Translator
 }
 
+// RegisterTranslationsFunc registers the messages of a locale, e.g.
+// the RegisterDefaultTranslations functions of go-playground/validator/v10/translations
+type RegisterTranslationsFunc func(v *v10.Validate, trans ut.Translator) error
+
+// nameTags are the struct tags used to name fields in errors, in priority order
+var nameTags = []string{"json", "path", "header", "cookie", "query"}
+
 var (
 	ErrFailedToGetTranslator = errors.New("failed to get translator for `en`")
 	ErrValidationFailed      = errors.New("validation failed")
@@ -39,19 +48,29 @@ func NewValidatorV10() (*V10, error) {
 	}
 
 	validate.RegisterTagNameFunc(func(fld reflect.StructField) string {
-		name := strings.SplitN(fld.Tag.Get("json"), ",", 2)[0] //nolint:gomnd
+		for _, key := range nameTags {
+			tag, ok := fld.Tag.Lookup(key)
+			if !ok {
+				continue
+			}
+
+			name := strings.SplitN(tag, ",", 2)[0] //nolint:gomnd
 
-		// skip if tag key says it should be ignored
-		if name == "-" {
-			return ""
+			// skip if tag key says it should be ignored
+			if name == "-" {
+				return ""
+			}
+
+			return name
 		}
 
-		return name
+		return ""
 	})
 
 	instance := V10{
 		validate: validate,
-		trans:    trans,
+		uni:      uni,
+		fallback: trans,
 	}
 
 	return &instance, nil
@@ -66,8 +85,79 @@ func (v V10) ValidateStruct(input any) error {
 	return nil
 }
 
+// RegisterLocale adds a locale that messages can be translated to.
+// Registering a locale twice replaces the previous translations.
+func (v V10) RegisterLocale(locale locales.Translator, register RegisterTranslationsFunc) error {
+	if err := v.uni.AddTranslator(locale, true); err != nil {
+		return fmt.Errorf("failed to add translator for `%s`: %w", locale.Locale(), err)
+	}
+
+	trans, ok := v.uni.GetTranslator(locale.Locale())
+	if !ok {
+		return fmt.Errorf("failed to get translator for `%s`", locale.Locale())
+	}
+
+	if err := register(v.validate, trans); err != nil {
+		return fmt.Errorf("failed to register `%s` translations: %w", locale.Locale(), err)
+	}
+
+	return nil
+}
+
+// RegisterValidation adds a custom validation tag with its English message.
+// Messages can use {0} for the field name and {1} for the tag parameter.
+func (v V10) RegisterValidation(tag string, fn v10.Func, message string) error {
+	if err := v.validate.RegisterValidation(tag, fn); err != nil {
+		return fmt.Errorf("failed to register `%s` validation: %w", tag, err)
+	}
+
+	return v.RegisterTranslation(v.fallback.Locale(), tag, message)
+}
+
+// RegisterTranslation sets the message of a validation tag in a registered locale
+func (v V10) RegisterTranslation(locale, tag, message string) error {
+	trans, ok := v.uni.GetTranslator(locale)
+	if !ok {
+		return fmt.Errorf("failed to register `%s` message: locale `%s` is not registered", tag, locale)
+	}
+
+	register := func(trans ut.Translator) error {
+		return trans.Add(tag, message, true)
+	}
+
+	translate := func(trans ut.Translator, fe v10.FieldError) string {
+		msg, err := trans.T(fe.Tag(), fe.Field(), fe.Param())
+		if err != nil {
+			return fe.Error()
+		}
+
+		return msg
+	}
+
+	if err := v.validate.RegisterTranslation(tag, trans, register, translate); err != nil {
+		return fmt.Errorf("failed to register `%s` message: %w", tag, err)
+	}
+
+	return nil
+}
+
+// RegisterStructValidation adds a struct level validation for the given types
+func (v V10) RegisterStructValidation(fn v10.StructLevelFunc, types ...any) {
+	v.validate.RegisterStructValidation(fn, types...)
+}
+
+// translator returns the translator of the first supported locale or English
+func (v V10) translator(locales []string) ut.Translator {
+	trans, ok := v.uni.FindTranslator(locales...)
+	if !ok {
+		return v.fallback
+	}
+
+	return trans
+}
+
 // Translate implements validator.Validator interface.
-func (v V10) Translate(err error) []Error {
+func (v V10) Translate(err error, locales ...string) []Error {
 	var verrs v10.ValidationErrors
 
 	if !errors.As(err, &verrs) {
@@ -78,14 +168,31 @@ func (v V10) Translate(err error) []Error {
 		}
 	}
 
+	trans := v.translator(locales)
 	errs := make([]Error, len(verrs))
 
 	for idx, field := range verrs {
+		msg := field.Translate(trans)
+
+		// Tags without a message in the locale fall back to English
+		if msg == field.Error() && trans != v.fallback {
+			msg = field.Translate(v.fallback)
+		}
+
 		errs[idx] = Error{
 			Field:   field.Field(),
-			Message: field.Translate(v.trans),
+			Message: msg,
 		}
 	}
 
 	return errs
 }
+
+// ValidateVar validates a single value using the rules of a `validate` tag
+func (v V10) ValidateVar(value any, tag string) error {
+	if err := v.validate.Var(value, tag); err != nil {
+		return errors.Join(ErrValidationFailed, err)
+	}
+
+	return nil
+}
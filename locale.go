@@ -0,0 +1,110 @@
+package fast
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/esequiel378/fast/internal/validator"
+	"github.com/go-playground/locales"
+	ut "github.com/go-playground/universal-translator"
+	v10 "github.com/go-playground/validator/v10"
+)
+
+// ErrLocaleNotSupported is returned by New when a locale is registered
+// on a validator that can not translate messages
+var ErrLocaleNotSupported = errors.New("validator does not support locales")
+
+// localeKey is the Locals key of the locales set with Context.SetLocale
+type localeKey struct{}
+
+// localeRegistrar is implemented by validators that support more locales
+type localeRegistrar interface {
+	RegisterLocale(locale locales.Translator, register validator.RegisterTranslationsFunc) error
+}
+
+// WithLocale registers a locale for validation messages, backed by the
+// go-playground translations. English is always registered and used
+// when none of the request locales is supported.
+//
+//	fast.New(
+//		fast.WithLocale(es.New(), estranslations.RegisterDefaultTranslations),
+//		fast.WithLocale(pt_BR.New(), ptbrtranslations.RegisterDefaultTranslations),
+//	)
+func WithLocale(locale locales.Translator, register func(v *v10.Validate, trans ut.Translator) error) func(*App) {
+	return func(a *App) {
+		registrar, ok := a.config.validator.(localeRegistrar)
+		if !ok {
+			a.err = errors.Join(a.err, ErrLocaleNotSupported)
+			return
+		}
+
+		if err := registrar.RegisterLocale(locale, register); err != nil {
+			a.err = errors.Join(a.err, err)
+		}
+	}
+}
+
+// parseAcceptLanguage returns the locales of an Accept-Language header
+// sorted by quality, e.g. "pt-BR, en;q=0.8" returns [pt_BR pt en].
+// The base language follows each regional locale, so "pt" messages
+// are used when there are no "pt_BR" ones.
+func parseAcceptLanguage(header string) []string {
+	if header == "" {
+		return nil
+	}
+
+	type weighted struct {
+		locale  string
+		quality float64
+	}
+
+	var tags []weighted
+
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+
+		tag = strings.TrimSpace(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range strings.Split(params, ";") {
+			key, value, ok := strings.Cut(param, "=")
+			if !ok || strings.TrimSpace(key) != "q" {
+				continue
+			}
+
+			if parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+				quality = parsed
+			}
+		}
+
+		if quality <= 0 {
+			continue
+		}
+
+		tags = append(tags, weighted{
+			locale:  strings.ReplaceAll(tag, "-", "_"),
+			quality: quality,
+		})
+	}
+
+	slices.SortStableFunc(tags, func(a, b weighted) int {
+		return cmp.Compare(b.quality, a.quality)
+	})
+
+	result := make([]string, 0, len(tags)*2)
+	for _, tag := range tags {
+		result = append(result, tag.locale)
+
+		if base, _, ok := strings.Cut(tag.locale, "_"); ok {
+			result = append(result, base)
+		}
+	}
+
+	return result
+}
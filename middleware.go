@@ -1,3 +1,87 @@
 package fast
 
+import (
+	"fmt"
+	"reflect"
+)
+
 type Middleware = func(ctx *Context) error
+
+// MiddlewareBuilder is the builder for creating typed middlewares
+type MiddlewareBuilder[I, O any] struct {
+	security SecurityRequirement
+}
+
+// TypedMiddleware creates a new builder for a middleware with a typed input and output.
+// The input is bound and validated the same way as an endpoint input, and the
+// output is stored on the request so downstream handlers can read it with Value.
+// The body is only read when the input has fields without a binding tag, so
+// typed middlewares also work in front of raw endpoints with any body.
+//
+//	auth := fast.
+//		TypedMiddleware[AuthIn, Principal]().
+//		Handle(func(c *fast.Context, in AuthIn) (Principal, error) {
+//			return lookupPrincipal(in.Token)
+//		})
+func TypedMiddleware[I, O any]() *MiddlewareBuilder[I, O] {
+	return &MiddlewareBuilder[I, O]{}
+}
+
+// Security documents that every handler the middleware protects requires
+// the given security scheme, e.g. for a middleware that checks an API key
+func (b *MiddlewareBuilder[I, O]) Security(scheme string, scopes ...string) *MiddlewareBuilder[I, O] {
+	b.security = b.security.with(scheme, scopes)
+	return b
+}
+
+// Handle finalizes the builder and returns a Middleware that can be used
+// on groups and endpoints
+func (b *MiddlewareBuilder[I, O]) Handle(fn func(*Context, I) (O, error)) Middleware {
+	plan := newBindingPlan(reflect.TypeFor[I]())
+	if plan.err != nil {
+		panic(fmt.Sprintf("invalid middleware input %s: %s", reflect.TypeFor[I](), plan.err))
+	}
+
+	middleware := func(c *Context) error {
+		var input I
+
+		if err := plan.decode(c, &input); err != nil {
+			return err
+		}
+
+		output, err := fn(c, input)
+		if err != nil {
+			return err
+		}
+
+		c.Locals(valueKey[O]{}, output)
+
+		return nil
+	}
+
+	if b.security != nil {
+		setMiddlewareSecurity(middleware, b.security)
+	}
+
+	return middleware
+}
+
+// valueKey is the request locals key for the values produced by typed middlewares
+type valueKey[O any] struct{}
+
+// Value returns the value of type O produced by a typed middleware on this request.
+// Values are keyed by type, so the last middleware producing an O wins.
+func Value[O any](c *Context) (O, bool) {
+	value, ok := c.Locals(valueKey[O]{}).(O)
+	return value, ok
+}
+
+// MustValue is like Value but panics if no middleware produced a value of type O
+func MustValue[O any](c *Context) O {
+	value, ok := Value[O](c)
+	if !ok {
+		panic(fmt.Sprintf("no middleware value of type %s", reflect.TypeFor[O]()))
+	}
+
+	return value
+}
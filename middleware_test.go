@@ -0,0 +1,144 @@
+package fast
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type principal struct {
+	Username string
+}
+
+func authMiddleware() Middleware {
+	type In struct {
+		Token string `header:"Authorization" validate:"required"`
+	}
+
+	return TypedMiddleware[In, principal]().
+		Handle(func(_ *Context, in In) (principal, error) {
+			if in.Token != "Bearer secret" {
+				return principal{}, UnauthorizedError("invalid token")
+			}
+
+			return principal{Username: "ada"}, nil
+		})
+}
+
+type webhookHandler struct{}
+
+func (webhookHandler) HandleReceive() Handler {
+	return RawEndpoint().
+		Method(http.MethodPost).
+		Handle(func(c *Context) error {
+			return c.SendString(MustValue[principal](c).Username + ":" + string(c.Body()))
+		})
+}
+
+type userHandler struct{}
+
+func (userHandler) HandleUpdate() Handler {
+	type In struct {
+		ID int `path:"id"`
+	}
+
+	return Endpoint[In, int]().
+		Method(http.MethodPatch).
+		Path("/:id").
+		Handle(func(c *Context, in In) (int, error) {
+			return in.ID, nil
+		})
+}
+
+func TestTypedMiddlewareBinding(t *testing.T) {
+	app := newTestApp(t)
+	app.Group("/hooks", authMiddleware()).MustRegister("", webhookHandler{})
+
+	tests := []struct {
+		name        string
+		body        string
+		contentType string
+		token       string
+		wantStatus  int
+		wantBody    string
+	}{
+		{
+			name:        "token in the body is ignored",
+			body:        `{"token":"Bearer secret","Token":"Bearer secret"}`,
+			contentType: "application/json",
+			wantStatus:  http.StatusUnprocessableEntity,
+		},
+		{
+			name:        "binary body",
+			body:        "\x00\x01",
+			contentType: "application/octet-stream",
+			token:       "Bearer secret",
+			wantStatus:  http.StatusOK,
+			wantBody:    "ada:\x00\x01",
+		},
+		{
+			name:        "text body",
+			body:        "hello",
+			contentType: "text/plain",
+			token:       "Bearer secret",
+			wantStatus:  http.StatusOK,
+			wantBody:    "ada:hello",
+		},
+		{
+			name:        "JSON array body",
+			body:        `[1,2]`,
+			contentType: "application/json",
+			token:       "Bearer secret",
+			wantStatus:  http.StatusOK,
+			wantBody:    "ada:[1,2]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/hooks", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			if tt.token != "" {
+				req.Header.Set("Authorization", tt.token)
+			}
+
+			status, body := send(t, app, req)
+			if status != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", status, tt.wantStatus, body)
+			}
+
+			if tt.wantBody != "" && body != tt.wantBody {
+				t.Errorf("body = %q, want %q", body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestMiddlewareCallingNext(t *testing.T) {
+	var after []string
+
+	app := newTestApp(t)
+	app.Group("/users", func(c *Context) error {
+		err := c.Next()
+		after = append(after, string(c.Response().Body()))
+		return err
+	}, func(c *Context) error {
+		return nil
+	}).MustRegister("", userHandler{})
+
+	resp, err := app.server.Test(httptest.NewRequest(http.MethodPatch, "/users/5", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || string(body) != "5" {
+		t.Fatalf("got %d %s, want 200 5", resp.StatusCode, body)
+	}
+
+	if len(after) != 1 || after[0] != "5" {
+		t.Errorf("code after Next saw %q, want the handler response once", after)
+	}
+}
@@ -2,26 +2,72 @@ package fast
 
 import (
 	"encoding/json"
+	"maps"
+	"net/http"
 	"path"
 	"reflect"
 	"slices"
+	"strconv"
 	"strings"
+	"sync"
+
+	"github.com/esequiel378/fast/internal/validator"
 )
 
 // OpenAPIInfo contains basic information about the API
 type OpenAPIInfo struct {
-	Title       string `json:"title"`
+	Title          string         `json:"title"`
+	Description    string         `json:"description,omitempty"`
+	TermsOfService string         `json:"termsOfService,omitempty"`
+	Contact        *ContactObject `json:"contact,omitempty"`
+	License        *LicenseObject `json:"license,omitempty"`
+	Version        string         `json:"version"`
+}
+
+// ContactObject is the contact information of the API
+type ContactObject struct {
+	Name  string `json:"name,omitempty"`
+	URL   string `json:"url,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+// LicenseObject is the license of the API
+type LicenseObject struct {
+	Name string `json:"name"`
+	// Identifier is an SPDX license expression, only valid in OpenAPI 3.1
+	Identifier string `json:"identifier,omitempty"`
+	URL        string `json:"url,omitempty"`
+}
+
+// ServerObject is a server that hosts the API
+type ServerObject struct {
+	URL         string                          `json:"url"`
+	Description string                          `json:"description,omitempty"`
+	Variables   map[string]ServerVariableObject `json:"variables,omitempty"`
+}
+
+// ServerVariableObject is a variable of a server URL template, e.g. {region}
+type ServerVariableObject struct {
+	Enum        []string `json:"enum,omitempty"`
+	Default     string   `json:"default"`
+	Description string   `json:"description,omitempty"`
+}
+
+// ExternalDocsObject links to additional documentation
+type ExternalDocsObject struct {
 	Description string `json:"description,omitempty"`
-	Version     string `json:"version"`
+	URL         string `json:"url"`
 }
 
 // OpenAPISchema represents the OpenAPI schema specification
 type OpenAPISchema struct {
-	OpenAPI    string                    `json:"openapi"`
-	Info       OpenAPIInfo               `json:"info"`
-	Paths      map[string]PathItemObject `json:"paths"`
-	Components ComponentsObject          `json:"components"`
-	Tags       []TagObject               `json:"tags,omitempty"` // Added Tags field
+	OpenAPI      string                    `json:"openapi"`
+	Info         OpenAPIInfo               `json:"info"`
+	Servers      []ServerObject            `json:"servers,omitempty"`
+	Paths        map[string]PathItemObject `json:"paths"`
+	Components   ComponentsObject          `json:"components"`
+	Tags         []TagObject               `json:"tags,omitempty"` // Added Tags field
+	ExternalDocs *ExternalDocsObject       `json:"externalDocs,omitempty"`
 }
 
 // TagObject represents an OpenAPI tag
@@ -42,6 +88,8 @@ type OperationObject struct {
 	Parameters  []ParameterObject         `json:"parameters,omitempty"`
 	RequestBody *RequestBodyObject        `json:"requestBody,omitempty"`
 	Responses   map[string]ResponseObject `json:"responses"`
+	Deprecated  bool                      `json:"deprecated,omitempty"`
+	Security    []SecurityRequirement     `json:"security,omitempty"`
 }
 
 // ParameterObject describes a single operation parameter
@@ -51,6 +99,7 @@ type ParameterObject struct {
 	Description string       `json:"description,omitempty"`
 	Required    bool         `json:"required"`
 	Schema      SchemaObject `json:"schema"`
+	Example     any          `json:"example,omitempty"`
 }
 
 // RequestBodyObject describes a request body
@@ -62,54 +111,214 @@ type RequestBodyObject struct {
 
 // MediaTypeObject provides schema for the media type
 type MediaTypeObject struct {
-	Schema SchemaObject `json:"schema"`
+	Schema  SchemaObject `json:"schema"`
+	Example any          `json:"example,omitempty"`
 }
 
 // ResponseObject describes a single response from an API operation
 type ResponseObject struct {
 	Description string                     `json:"description"`
+	Headers     map[string]HeaderObject    `json:"headers,omitempty"`
 	Content     map[string]MediaTypeObject `json:"content,omitempty"`
 }
 
+// HeaderObject describes a single response header
+type HeaderObject struct {
+	Description string       `json:"description,omitempty"`
+	Schema      SchemaObject `json:"schema"`
+}
+
 // SchemaObject describes the object schema
 type SchemaObject struct {
 	Type       string                  `json:"type,omitempty"`
 	Format     string                  `json:"format,omitempty"`
+	Pattern    string                  `json:"pattern,omitempty"`
 	Properties map[string]SchemaObject `json:"properties,omitempty"`
 	Items      *SchemaObject           `json:"items,omitempty"`
-	Ref        string                  `json:"$ref,omitempty"`
-	Required   []string                `json:"required,omitempty"`
+	// AdditionalProperties is the schema of the values of maps
+	AdditionalProperties *SchemaObject `json:"additionalProperties,omitempty"`
+	Ref                  string        `json:"$ref,omitempty"`
+	Required             []string      `json:"required,omitempty"`
+	// AllOf wraps a $ref that needs sibling keywords, such as nullable
+	AllOf []SchemaObject `json:"allOf,omitempty"`
+	// AnyOf makes a $ref nullable in OpenAPI 3.1
+	AnyOf       []SchemaObject `json:"anyOf,omitempty"`
+	Nullable    bool           `json:"nullable,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Example     any            `json:"example,omitempty"`
+	Deprecated  bool           `json:"deprecated,omitempty"`
+	// Constraints, mapped from the `validate` tags
+	Enum             []any    `json:"enum,omitempty"`
+	Minimum          *float64 `json:"minimum,omitempty"`
+	Maximum          *float64 `json:"maximum,omitempty"`
+	ExclusiveMinimum bool     `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum bool     `json:"exclusiveMaximum,omitempty"`
+	MinLength        *int     `json:"minLength,omitempty"`
+	MaxLength        *int     `json:"maxLength,omitempty"`
+	MinItems         *int     `json:"minItems,omitempty"`
+	MaxItems         *int     `json:"maxItems,omitempty"`
+	MinProperties    *int     `json:"minProperties,omitempty"`
+	MaxProperties    *int     `json:"maxProperties,omitempty"`
+
+	// draft2020 encodes the schema for OpenAPI 3.1, see MarshalJSON
+	draft2020 bool
+}
+
+// clone returns a deep copy of the schema and its subschemas
+func (s SchemaObject) clone() SchemaObject {
+	if s.Properties != nil {
+		properties := make(map[string]SchemaObject, len(s.Properties))
+		for name, property := range s.Properties {
+			properties[name] = property.clone()
+		}
+		s.Properties = properties
+	}
+
+	if s.Items != nil {
+		items := s.Items.clone()
+		s.Items = &items
+	}
+
+	if s.AdditionalProperties != nil {
+		values := s.AdditionalProperties.clone()
+		s.AdditionalProperties = &values
+	}
+
+	for _, subschemas := range []*[]SchemaObject{&s.AllOf, &s.AnyOf} {
+		if *subschemas == nil {
+			continue
+		}
+
+		cloned := make([]SchemaObject, len(*subschemas))
+		for idx, subschema := range *subschemas {
+			cloned[idx] = subschema.clone()
+		}
+		*subschemas = cloned
+	}
+
+	s.Required = slices.Clone(s.Required)
+	s.Enum = slices.Clone(s.Enum)
+
+	return s
 }
 
 // ComponentsObject holds schemas that can be reused
 type ComponentsObject struct {
-	Schemas map[string]SchemaObject `json:"schemas,omitempty"`
+	Schemas         map[string]SchemaObject         `json:"schemas,omitempty"`
+	SecuritySchemes map[string]SecuritySchemeObject `json:"securitySchemes,omitempty"`
+}
+
+// endpointDocs is the documentation of an operation set on the endpoint builders
+type endpointDocs struct {
+	summary     string
+	description string
+	tags        []string
+	operationID string
+	deprecated  bool
+	security    SecurityRequirement
+}
+
+// endpointExample is an example of the input and output of an endpoint
+type endpointExample struct {
+	input  any
+	output any
+}
+
+// endpointMeta is the documentation a handler carries besides its types
+type endpointMeta struct {
+	docs    endpointDocs
+	example *endpointExample
+	status  int
+	headers map[string]string
+	errors  []ErrorResponse
+}
+
+// metaHandler is implemented by handlers that carry documentation
+type metaHandler interface {
+	meta() endpointMeta
+}
+
+// documentedHandler is a handler registered on the generator with its full path
+type documentedHandler struct {
+	path    string
+	handler Handler
+	// security is required by the group of the handler
+	security SecurityRequirement
 }
 
 // OpenAPIGenerator is responsible for creating OpenAPI documentation
 type OpenAPIGenerator struct {
-	handlers     map[string]Handler
+	handlers     []documentedHandler
 	info         OpenAPIInfo
+	version      string
+	servers      []ServerObject
+	externalDocs *ExternalDocsObject
 	schemas      map[string]SchemaObject
 	tagsByName   map[string]TagObject // Map to store unique tags
 	tagsForPaths map[string][]string  // Store tag associations for paths
+	// names and taken map Go types to their unique component names
+	names map[reflect.Type]string
+	taken map[string]reflect.Type
+	// bodyNames map input types with bound fields to the component
+	// name of their request body
+	bodyNames map[reflect.Type]string
+	// types maps well-known and registered types to their schemas
+	types map[reflect.Type]SchemaObject
+	// securitySchemes are documented in the components
+	securitySchemes map[string]SecuritySchemeObject
+	// problemDetails documents error responses as application/problem+json
+	problemDetails bool
+
+	// mu guards the cached document, dropped when a handler is registered
+	mu     sync.Mutex
+	cached *openAPIDocument
 }
 
 // NewOpenAPIGenerator creates a new instance of OpenAPIGenerator
 func NewOpenAPIGenerator(info OpenAPIInfo) *OpenAPIGenerator {
 	return &OpenAPIGenerator{
-		handlers:     make(map[string]Handler),
 		info:         info,
+		version:      OpenAPI30,
 		schemas:      make(map[string]SchemaObject),
 		tagsByName:   make(map[string]TagObject),
 		tagsForPaths: make(map[string][]string),
+		names:        make(map[reflect.Type]string),
+		taken:        make(map[string]reflect.Type),
+		bodyNames:    make(map[reflect.Type]string),
+		types:        wellKnownTypes(),
+
+		securitySchemes: make(map[string]SecuritySchemeObject),
 	}
 }
 
 // RegisterHandler adds a handler to be documented
 func (g *OpenAPIGenerator) RegisterHandler(rootPath string, handler Handler) {
+	g.registerHandler(rootPath, handler, nil)
+}
+
+// registerHandler adds a handler to be documented with the security of its group
+func (g *OpenAPIGenerator) registerHandler(rootPath string, handler Handler, security SecurityRequirement) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.cached = nil
+
 	path := path.Join(rootPath, handler.Path())
-	g.handlers[path] = handler
+	g.handlers = append(g.handlers, documentedHandler{
+		path:     path,
+		handler:  handler,
+		security: security,
+	})
+
+	// Tags declared on the endpoint replace the ones guessed from the path
+	if h, ok := handler.(metaHandler); ok && len(h.meta().docs.tags) > 0 {
+		for _, tag := range h.meta().docs.tags {
+			if _, exists := g.tagsByName[tag]; !exists {
+				g.tagsByName[tag] = TagObject{Name: tag}
+			}
+		}
+		return
+	}
 
 	// Auto-generate tag for this path
 	g.generateTagsForPath(path)
@@ -131,7 +340,7 @@ func (g *OpenAPIGenerator) generateTagsForPath(pathStr string) {
 
 	// Use the first segment as the primary tag
 	primaryTag := segments[0]
-	if primaryTag == "" {
+	if primaryTag == "" || isDynamicSegment(primaryTag) {
 		return
 	}
 
@@ -150,7 +359,7 @@ func (g *OpenAPIGenerator) generateTagsForPath(pathStr string) {
 	g.tagsForPaths[pathStr] = []string{tagName}
 
 	// If path has a second segment, consider it a sub-resource
-	if len(segments) > 1 && segments[1] != "" {
+	if len(segments) > 1 && segments[1] != "" && !isDynamicSegment(segments[1]) {
 		// For paths like /admin/users, we might want a secondary tag "Admin Users"
 		subTag := toTitleCase(primaryTag + " " + segments[1])
 
@@ -184,35 +393,59 @@ func toTitleCase(s string) string {
 	return strings.Join(words, " ")
 }
 
-// GenerateSchema generates the OpenAPI schema for all registered handlers
+// GenerateSchema generates the OpenAPI schema for all registered handlers.
+// The schema is a copy, changing it does not affect later generations.
 func (g *OpenAPIGenerator) GenerateSchema() (*OpenAPISchema, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.generateSchema()
+}
+
+// generateSchema generates the OpenAPI schema, the caller must hold g.mu
+func (g *OpenAPIGenerator) generateSchema() (*OpenAPISchema, error) {
 	schema := &OpenAPISchema{
-		OpenAPI: "3.0.3",
-		Info:    g.info,
-		Paths:   make(map[string]PathItemObject),
+		OpenAPI:      OpenAPI30,
+		Info:         g.info,
+		Servers:      slices.Clone(g.servers),
+		ExternalDocs: g.externalDocs,
+		Paths:        make(map[string]PathItemObject),
 		Components: ComponentsObject{
-			Schemas: make(map[string]SchemaObject),
+			Schemas:         make(map[string]SchemaObject),
+			SecuritySchemes: maps.Clone(g.securitySchemes),
 		},
 	}
 
+	// Keep the problem schema names before any Go type can take them
+	if g.problemDetails {
+		g.reserveName("ProblemDetails")
+		g.reserveName("ValidationProblemDetails")
+	}
+
 	// Process each handler to build paths
-	for path, handler := range g.handlers {
-		g.processHandler(path, schema, handler)
+	for _, h := range g.handlers {
+		g.processHandler(h.path, schema, h.handler, h.security)
 	}
 
-	// Add collected schemas to components
-	schema.Components.Schemas = g.schemas
+	// Add collected schemas to components, copied since they are kept for the next generation
+	for name, component := range g.schemas {
+		schema.Components.Schemas[name] = component.clone()
+	}
 
-	// Convert tags map to slice for the OpenAPI schema
-	for _, tag := range g.tagsByName {
-		schema.Tags = append(schema.Tags, tag)
+	// Convert tags map to slice for the OpenAPI schema, sorted so the output is stable
+	for _, name := range slices.Sorted(maps.Keys(g.tagsByName)) {
+		schema.Tags = append(schema.Tags, g.tagsByName[name])
+	}
+
+	if g.version == OpenAPI31 {
+		schema.convertTo31()
 	}
 
 	return schema, nil
 }
 
 // processHandler processes a single handler to extract path, method, and schemas
-func (g *OpenAPIGenerator) processHandler(path string, schema *OpenAPISchema, handler Handler) {
+func (g *OpenAPIGenerator) processHandler(path string, schema *OpenAPISchema, handler Handler, security SecurityRequirement) {
 	method := strings.ToLower(handler.Method())
 
 	var (
@@ -220,260 +453,214 @@ func (g *OpenAPIGenerator) processHandler(path string, schema *OpenAPISchema, ha
 		outputType = reflect.TypeOf(handler.OutputSerializer())
 	)
 
+	// Fiber parameters and wildcards become OpenAPI path templates
+	template, pathParams := openAPIPath(path)
+
 	// Create or update path
-	if _, exists := schema.Paths[path]; !exists {
-		schema.Paths[path] = make(PathItemObject)
+	if _, exists := schema.Paths[template]; !exists {
+		schema.Paths[template] = make(PathItemObject)
 	}
 
 	// Create operation
 	operation := OperationObject{
-		OperationID: method + strings.ReplaceAll(path, "/", "_"),
+		OperationID: operationID(method, template),
 		Responses:   make(map[string]ResponseObject),
 	}
 
+	var meta endpointMeta
+	if h, ok := handler.(metaHandler); ok {
+		meta = h.meta()
+	}
+
+	operation.Security = g.operationSecurity(security.merge(meta.docs.security))
+	operation.Summary = meta.docs.summary
+	operation.Description = meta.docs.description
+	operation.Deprecated = meta.docs.deprecated
+
+	if meta.docs.operationID != "" {
+		operation.OperationID = meta.docs.operationID
+	}
+
 	// Add tags to the operation
-	if tags, exists := g.tagsForPaths[path]; exists && len(tags) > 0 {
+	operation.Tags = meta.docs.tags
+	if tags, exists := g.tagsForPaths[path]; exists && len(tags) > 0 && len(operation.Tags) == 0 {
 		operation.Tags = tags
 	}
 
-	// Add request body for non-GET methods
-	if method != "get" && inputType != nil {
-		inputSchema := g.generateSchemaForType(inputType)
-		inputName := inputType.Name()
-		if inputName != "" && inputName != "In" {
-			g.schemas[inputName] = inputSchema
+	var inputExample, outputExample any
+	if meta.example != nil {
+		inputExample, outputExample = meta.example.input, meta.example.output
+	}
+
+	// Add request body for methods that have one, without the bound fields
+	hasBody := method != "get" && method != "head"
+	if hasBody && inputType != nil {
+		if inputSchema, ok := g.requestBodySchema(inputType); ok {
 			operation.RequestBody = &RequestBodyObject{
 				Content: map[string]MediaTypeObject{
 					"application/json": {
-						Schema: SchemaObject{
-							Ref: "#/components/schemas/" + inputName,
-						},
+						Schema:  inputSchema,
+						Example: bodyExample(inputType, inputExample),
 					},
 				},
 				Required: true,
 			}
 		}
-	} else if inputType != nil && method == "get" {
-		// For GET requests, add parameters from the input type
-		operation.Parameters = g.generateParametersForType(inputType)
 	}
 
+	operation.Parameters = g.generateParameters(pathParams, inputType, hasBody)
+
 	// Add response
+	status := meta.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	var response ResponseObject
+
 	if outputType != nil {
-		outputSchema := g.generateSchemaForType(outputType)
-		outputName := outputType.Name()
-		if outputName != "" && outputName != "Out" {
-			g.schemas[outputName] = outputSchema
-			operation.Responses["200"] = ResponseObject{
-				Description: "Successful operation",
-				Content: map[string]MediaTypeObject{
-					"application/json": {
-						Schema: SchemaObject{
-							Ref: "#/components/schemas/" + outputName,
-						},
-					},
-				},
-			}
-		} else {
-			// Default output response
-			operation.Responses["200"] = ResponseObject{
-				Description: "Successful operation",
-				Content: map[string]MediaTypeObject{
-					"application/json": {
-						Schema: outputSchema,
-					},
+		// Named structs are referenced from the components
+		response = ResponseObject{
+			Description: "Successful operation",
+			Content: map[string]MediaTypeObject{
+				"application/json": {
+					Schema:  g.generateSchemaForType(outputType),
+					Example: outputExample,
 				},
-			}
+			},
 		}
 	} else {
 		// Default response if no output type is found
-		operation.Responses["200"] = ResponseObject{
+		response = ResponseObject{
 			Description: "Successful operation",
 		}
 	}
 
-	// Add error responses
-	operation.Responses["400"] = ResponseObject{
-		Description: "Bad request",
-	}
-	operation.Responses["422"] = ResponseObject{
-		Description: "Validation error",
-	}
-	operation.Responses["500"] = ResponseObject{
-		Description: "Internal server error",
+	if !bodyAllowed(status) {
+		response.Content = nil
 	}
 
-	schema.Paths[path][method] = operation
-}
-
-// generateSchemaForType generates an OpenAPI schema for a Go type
-func (g *OpenAPIGenerator) generateSchemaForType(t reflect.Type) SchemaObject {
-	if t == nil {
-		return SchemaObject{Type: "object"}
-	}
-
-	// Dereference pointer if needed
-	if t.Kind() == reflect.Ptr {
-		t = t.Elem()
-	}
-
-	switch t.Kind() {
-	case reflect.Struct:
-		schema := SchemaObject{
-			Type:       "object",
-			Properties: make(map[string]SchemaObject),
+	for name, value := range meta.headers {
+		if response.Headers == nil {
+			response.Headers = make(map[string]HeaderObject)
 		}
 
-		var required []string
-
-		for i := range t.NumField() {
-			field := t.Field(i)
-
-			// Skip unexported fields
-			if !field.IsExported() {
-				continue
-			}
-
-			// Get JSON tag
-			tag := field.Tag.Get("json")
-			if tag == "-" {
-				continue
-			}
-
-			// Parse the tag
-			parts := strings.Split(tag, ",")
-			name := parts[0]
-			if name == "" {
-				name = field.Name
-			}
-
-			// Check if required (field is required if omitempty is NOT present)
-			isRequired := !slices.Contains(parts[1:], "omitempty")
-
-			if isRequired {
-				required = append(required, name)
-			}
-
-			// Generate schema for the field
-			fieldSchema := g.generateSchemaForType(field.Type)
-			schema.Properties[name] = fieldSchema
+		response.Headers[name] = HeaderObject{
+			Description: "Set to `" + value + "`",
+			Schema:      SchemaObject{Type: "string"},
 		}
+	}
 
-		if len(required) > 0 {
-			schema.Required = required
-		}
+	operation.Responses[strconv.Itoa(status)] = response
 
-		return schema
+	// Add error responses, handlers without input never fail binding or validation
+	if inputType != nil {
+		operation.Responses["400"] = g.errorResponse("Bad request", true)
+		operation.Responses["422"] = g.errorResponse("Validation error", true)
+	}
+	operation.Responses["500"] = g.errorResponse("Internal server error", false)
 
-	case reflect.Slice, reflect.Array:
-		// Get the element type
-		elemType := t.Elem()
+	for _, declared := range meta.errors {
+		operation.Responses[strconv.Itoa(declared.Status)] = g.declaredErrorResponse(declared)
+	}
 
-		// Special case for []string since it's common
-		if elemType.Kind() == reflect.String {
-			return SchemaObject{
-				Type: "array",
-				Items: &SchemaObject{
-					Type: "string",
-				},
-			}
-		}
+	schema.Paths[template][method] = operation
+}
 
-		// For structs and other complex types
-		if elemType.Kind() == reflect.Struct {
-			// Add the struct type to schemas
-			typeName := elemType.Name()
-			if typeName != "" {
-				if _, exists := g.schemas[typeName]; !exists {
-					g.schemas[typeName] = g.generateSchemaForType(elemType)
-				}
-
-				return SchemaObject{
-					Type: "array",
-					Items: &SchemaObject{
-						Ref: "#/components/schemas/" + typeName,
-					},
-				}
+// errorResponse returns the documentation of an error response
+func (g *OpenAPIGenerator) errorResponse(description string, validation bool) ResponseObject {
+	if !g.problemDetails {
+		if !validation {
+			return ResponseObject{
+				Description: description,
 			}
 		}
 
-		// Default case for other array types
-		elemSchema := g.generateSchemaForType(elemType)
-		return SchemaObject{
-			Type:  "array",
-			Items: &SchemaObject{Type: elemSchema.Type},
-		}
-
-	case reflect.Map:
-		return SchemaObject{
-			Type: "object",
+		return ResponseObject{
+			Description: description,
+			Content: map[string]MediaTypeObject{
+				"application/json": {
+					Schema: g.generateSchemaForType(reflect.TypeFor[validator.ValidationErrorSerializer]()),
+				},
+			},
 		}
+	}
 
-	case reflect.String:
-		return SchemaObject{Type: "string"}
-
-	case reflect.Bool:
-		return SchemaObject{Type: "boolean"}
-
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
-		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		return SchemaObject{Type: "integer"}
+	name := "ProblemDetails"
+	if validation {
+		name = "ValidationProblemDetails"
+	}
 
-	case reflect.Float32, reflect.Float64:
-		return SchemaObject{Type: "number"}
+	g.registerProblemSchemas()
 
-	default:
-		return SchemaObject{Type: "object"}
+	return ResponseObject{
+		Description: description,
+		Content: map[string]MediaTypeObject{
+			MIMEApplicationProblemJSON: {
+				Schema: SchemaObject{
+					Ref: "#/components/schemas/" + name,
+				},
+			},
+		},
 	}
 }
 
-// generateParametersForType converts a struct type to query parameters
-func (g *OpenAPIGenerator) generateParametersForType(t reflect.Type) []ParameterObject {
-	if t == nil || t.Kind() != reflect.Struct {
-		return nil
+// declaredErrorResponse returns the documentation of an error response declared
+// by an endpoint. HTTPError bodies are documented as problem details when enabled.
+func (g *OpenAPIGenerator) declaredErrorResponse(declared ErrorResponse) ResponseObject {
+	description := declared.Description
+	if description == "" {
+		description = http.StatusText(declared.Status)
 	}
 
-	var parameters []ParameterObject
-
-	for i := range t.NumField() {
-		field := t.Field(i)
-
-		// Skip unexported fields
-		if !field.IsExported() {
-			continue
-		}
-
-		// Get JSON tag
-		tag := field.Tag.Get("json")
-		if tag == "-" {
-			continue
-		}
-
-		// Parse the tag
-		parts := strings.Split(tag, ",")
-		name := parts[0]
-		if name == "" {
-			name = field.Name
-		}
+	if declared.body == reflect.TypeFor[HTTPError]() && g.problemDetails {
+		return g.errorResponse(description, false)
+	}
 
-		// Check if required
-		isRequired := !slices.Contains(parts[1:], "omitempty")
+	return ResponseObject{
+		Description: description,
+		Content: map[string]MediaTypeObject{
+			"application/json": {
+				Schema: g.generateSchemaForType(declared.body),
+			},
+		},
+	}
+}
 
-		// Generate schema for the field
-		fieldSchema := g.generateSchemaForType(field.Type)
+// registerProblemSchemas adds the RFC 9457 problem details schemas to the components
+func (g *OpenAPIGenerator) registerProblemSchemas() {
+	if _, exists := g.schemas["ProblemDetails"]; exists {
+		return
+	}
 
-		// Create parameter
-		param := ParameterObject{
-			Name:     name,
-			In:       "query",
-			Required: isRequired,
-			Schema:   fieldSchema,
-		}
+	problem := SchemaObject{
+		Type: "object",
+		Properties: map[string]SchemaObject{
+			"type":     {Type: "string", Format: "uri-reference"},
+			"title":    {Type: "string"},
+			"status":   {Type: "integer"},
+			"detail":   {Type: "string"},
+			"instance": {Type: "string", Format: "uri-reference"},
+		},
+	}
 
-		parameters = append(parameters, param)
+	validationProblem := SchemaObject{
+		Type:       "object",
+		Properties: maps.Clone(problem.Properties),
+	}
+	validationProblem.Properties["errors"] = SchemaObject{
+		Type: "array",
+		Items: &SchemaObject{
+			Type: "object",
+			Properties: map[string]SchemaObject{
+				"field":   {Type: "string"},
+				"message": {Type: "string"},
+			},
+		},
 	}
 
-	return parameters
+	g.schemas["ProblemDetails"] = problem
+	g.schemas["ValidationProblemDetails"] = validationProblem
 }
 
 // GenerateJSON returns the OpenAPI schema as a JSON string
@@ -491,43 +678,17 @@ func (g *OpenAPIGenerator) GenerateJSON() (string, error) {
 	return string(data), nil
 }
 
-const swaggerUIHTML = `<!DOCTYPE html>
-<html lang="en">
-<head>
-    <meta charset="UTF-8">
-    <title>Swagger UI</title>
-    <link rel="stylesheet" type="text/css" href="https://unpkg.com/swagger-ui-dist@4.5.0/swagger-ui.css" />
-    <link rel="icon" type="image/png" href="https://unpkg.com/swagger-ui-dist@4.5.0/favicon-32x32.png" sizes="32x32" />
-    <link rel="icon" type="image/png" href="https://unpkg.com/swagger-ui-dist@4.5.0/favicon-16x16.png" sizes="16x16" />
-    <style>
-        html { box-sizing: border-box; overflow: -moz-scrollbars-vertical; overflow-y: scroll; }
-        *, *:before, *:after { box-sizing: inherit; }
-        body { margin: 0; background: #fafafa; }
-    </style>
-</head>
-
-<body>
-    <div id="swagger-ui"></div>
-
-    <script src="https://unpkg.com/swagger-ui-dist@4.5.0/swagger-ui-bundle.js" charset="UTF-8"> </script>
-    <script src="https://unpkg.com/swagger-ui-dist@4.5.0/swagger-ui-standalone-preset.js" charset="UTF-8"> </script>
-    <script>
-        window.onload = function () {
-            const ui = SwaggerUIBundle({
-                url: "/swagger.json",
-                dom_id: '#swagger-ui',
-                deepLinking: true,
-                presets: [
-                    SwaggerUIBundle.presets.apis,
-                    SwaggerUIStandalonePreset
-                ],
-                plugins: [
-                    SwaggerUIBundle.plugins.DownloadUrl
-                ],
-                layout: "StandaloneLayout"
-            });
-            window.ui = ui;
-        };
-    </script>
-</body>
-</html>`
+// GenerateYAML returns the OpenAPI schema as a YAML string
+func (g *OpenAPIGenerator) GenerateYAML() (string, error) {
+	data, err := g.GenerateJSON()
+	if err != nil {
+		return "", err
+	}
+
+	yaml, err := jsonToYAML([]byte(data))
+	if err != nil {
+		return "", err
+	}
+
+	return string(yaml), nil
+}
@@ -0,0 +1,176 @@
+package fast
+
+import (
+	"encoding/json"
+	"maps"
+)
+
+// OpenAPI versions the generator can output
+const (
+	// OpenAPI30 is the default version, understood by most tools
+	OpenAPI30 = "3.0.3"
+	// OpenAPI31 uses JSON Schema 2020-12 for the schemas
+	OpenAPI31 = "3.1.0"
+)
+
+// MarshalJSON encodes the schema, using the JSON Schema 2020-12 keywords
+// when the schema belongs to an OpenAPI 3.1 document
+func (s SchemaObject) MarshalJSON() ([]byte, error) {
+	type plain SchemaObject
+	if !s.draft2020 {
+		return json.Marshal(plain(s))
+	}
+
+	// The fields below shadow the ones of plain that changed in 2020-12,
+	// type is declared first to keep it at the top of the schema
+	out := struct {
+		Type any `json:"type,omitempty"`
+		plain
+		Nullable         bool     `json:"nullable,omitempty"`
+		Minimum          *float64 `json:"minimum,omitempty"`
+		Maximum          *float64 `json:"maximum,omitempty"`
+		ExclusiveMinimum *float64 `json:"exclusiveMinimum,omitempty"`
+		ExclusiveMaximum *float64 `json:"exclusiveMaximum,omitempty"`
+	}{
+		plain:   plain(s),
+		Minimum: s.Minimum,
+		Maximum: s.Maximum,
+	}
+
+	switch {
+	case s.Type != "" && s.Nullable:
+		out.Type = []string{s.Type, "null"}
+	case s.Type != "":
+		out.Type = s.Type
+	}
+
+	if s.ExclusiveMinimum {
+		out.ExclusiveMinimum, out.Minimum = s.Minimum, nil
+	}
+
+	if s.ExclusiveMaximum {
+		out.ExclusiveMaximum, out.Maximum = s.Maximum, nil
+	}
+
+	return json.Marshal(out)
+}
+
+// schema31 returns a copy of the schema and its subschemas in JSON Schema 2020-12
+func schema31(s SchemaObject) SchemaObject {
+	// $ref can have sibling keywords, so the allOf wrappers are not needed
+	if len(s.AllOf) == 1 && s.AllOf[0].Ref != "" && s.Type == "" {
+		ref := s.AllOf[0]
+		s.AllOf = nil
+
+		if s.Nullable {
+			s.Nullable = false
+			s.AnyOf = []SchemaObject{ref, {Type: "null"}}
+		} else {
+			s.Ref = ref.Ref
+		}
+	}
+
+	s.draft2020 = true
+
+	if s.Properties != nil {
+		properties := make(map[string]SchemaObject, len(s.Properties))
+		for name, property := range s.Properties {
+			properties[name] = schema31(property)
+		}
+		s.Properties = properties
+	}
+
+	if s.Items != nil {
+		items := schema31(*s.Items)
+		s.Items = &items
+	}
+
+	if s.AdditionalProperties != nil {
+		values := schema31(*s.AdditionalProperties)
+		s.AdditionalProperties = &values
+	}
+
+	s.AllOf = schemas31(s.AllOf)
+	s.AnyOf = schemas31(s.AnyOf)
+
+	return s
+}
+
+// schemas31 converts a list of schemas with schema31
+func schemas31(schemas []SchemaObject) []SchemaObject {
+	if schemas == nil {
+		return nil
+	}
+
+	converted := make([]SchemaObject, len(schemas))
+	for idx, schema := range schemas {
+		converted[idx] = schema31(schema)
+	}
+
+	return converted
+}
+
+// convertTo31 rewrites every schema of the document in JSON Schema 2020-12.
+// The components are copied, so the schemas cached by the generator are kept.
+func (s *OpenAPISchema) convertTo31() {
+	s.OpenAPI = OpenAPI31
+
+	components := make(map[string]SchemaObject, len(s.Components.Schemas))
+	for name, schema := range s.Components.Schemas {
+		components[name] = schema31(schema)
+	}
+	s.Components.Schemas = components
+
+	for _, item := range s.Paths {
+		for method, operation := range item {
+			item[method] = operation31(operation)
+		}
+	}
+}
+
+// operation31 converts the schemas of the parameters, body and responses of an operation
+func operation31(operation OperationObject) OperationObject {
+	for idx, param := range operation.Parameters {
+		operation.Parameters[idx].Schema = schema31(param.Schema)
+	}
+
+	if operation.RequestBody != nil {
+		body := *operation.RequestBody
+		body.Content = content31(body.Content)
+		operation.RequestBody = &body
+	}
+
+	responses := make(map[string]ResponseObject, len(operation.Responses))
+	for status, response := range operation.Responses {
+		response.Content = content31(response.Content)
+
+		if response.Headers != nil {
+			headers := maps.Clone(response.Headers)
+			for name, header := range headers {
+				header.Schema = schema31(header.Schema)
+				headers[name] = header
+			}
+			response.Headers = headers
+		}
+
+		responses[status] = response
+	}
+	operation.Responses = responses
+
+	return operation
+}
+
+// content31 converts the schemas of the media types of a body
+func content31(content map[string]MediaTypeObject) map[string]MediaTypeObject {
+	if content == nil {
+		return nil
+	}
+
+	converted := make(map[string]MediaTypeObject, len(content))
+	for mediaType, media := range content {
+		media.Schema = schema31(media.Schema)
+		converted[mediaType] = media
+	}
+
+	return converted
+}
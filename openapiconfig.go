@@ -0,0 +1,184 @@
+package fast
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// OpenAPIConfig configures the generated OpenAPI document and where it is served
+type OpenAPIConfig struct {
+	// Info is the title, version, contact and license of the API.
+	// The title defaults to Fast and the version to 0.0.1.
+	Info         OpenAPIInfo
+	Servers      []ServerObject
+	ExternalDocs *ExternalDocsObject
+	// Version is the OpenAPI version of the document, OpenAPI30 or OpenAPI31.
+	// Defaults to OpenAPI30.
+	Version string
+	// JSONPath serves the document as JSON, defaults to /swagger.json
+	JSONPath string
+	// YAMLPath serves the document as YAML, defaults to /openapi.yaml
+	YAMLPath string
+	// UIPath serves Swagger UI, defaults to /swagger
+	UIPath string
+	// Docs configures Swagger UI and the other documentation pages
+	Docs DocsConfig
+	// Middlewares run before the schema and the documentation pages
+	// are served, e.g. to protect them with authentication
+	Middlewares []Middleware
+}
+
+// ErrOpenAPIVersionNotSupported is returned by New when OpenAPIConfig.Version
+// is neither OpenAPI30 nor OpenAPI31
+var ErrOpenAPIVersionNotSupported = errors.New("OpenAPI version not supported")
+
+// WithOpenAPI enables the OpenAPI schema generator and serves the
+// schema and Swagger UI at the paths of the config, e.g.
+//
+//	fast.WithOpenAPI(fast.OpenAPIConfig{
+//		Info:    fast.OpenAPIInfo{Title: "Pet Store", Version: "1.0.0"},
+//		Servers: []fast.ServerObject{{URL: "https://api.example.com"}},
+//		Version: fast.OpenAPI31,
+//	})
+func WithOpenAPI(cfg OpenAPIConfig) func(*App) {
+	return func(a *App) {
+		cfg = cfg.withDefaults()
+
+		if cfg.Version != OpenAPI30 && cfg.Version != OpenAPI31 {
+			a.err = errors.Join(a.err, fmt.Errorf("%w: %q", ErrOpenAPIVersionNotSupported, cfg.Version))
+			return
+		}
+
+		a.apiSchema = NewOpenAPIGenerator(cfg.Info)
+		a.apiSchema.version = cfg.Version
+		a.apiSchema.servers = cfg.Servers
+		a.apiSchema.externalDocs = cfg.ExternalDocs
+		a.openAPI = &cfg
+	}
+}
+
+// withDefaults fills the empty fields of the config
+func (cfg OpenAPIConfig) withDefaults() OpenAPIConfig {
+	if cfg.Info.Title == "" {
+		cfg.Info.Title = "Fast"
+	}
+
+	if cfg.Info.Version == "" {
+		cfg.Info.Version = "0.0.1"
+	}
+
+	if cfg.Version == "" {
+		cfg.Version = OpenAPI30
+	}
+
+	if cfg.JSONPath == "" {
+		cfg.JSONPath = "/swagger.json"
+	}
+
+	if cfg.YAMLPath == "" {
+		cfg.YAMLPath = "/openapi.yaml"
+	}
+
+	if cfg.UIPath == "" {
+		cfg.UIPath = "/swagger"
+	}
+
+	return cfg
+}
+
+// openAPIDocument is the generated document in every served format
+type openAPIDocument struct {
+	json     []byte
+	jsonETag string
+	yaml     []byte
+	yamlETag string
+}
+
+// document returns the generated document, which is only
+// generated again after a handler or type is registered
+func (g *OpenAPIGenerator) document() (*openAPIDocument, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.cached != nil {
+		return g.cached, nil
+	}
+
+	schema, err := g.generateSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	yaml, err := jsonToYAML(data)
+	if err != nil {
+		return nil, err
+	}
+
+	g.cached = &openAPIDocument{
+		json:     data,
+		jsonETag: etag(data),
+		yaml:     yaml,
+		yamlETag: etag(yaml),
+	}
+
+	return g.cached, nil
+}
+
+// etag returns a strong ETag of the content
+func etag(content []byte) string {
+	hash := sha256.Sum256(content)
+	return fmt.Sprintf(`"%x"`, hash[:])
+}
+
+// serveOpenAPI registers the routes serving the document and the documentation pages
+func (a App) serveOpenAPI(cfg OpenAPIConfig) {
+	middlewares := wrapMiddlewares(a.config, cfg.Middlewares)
+	get := func(path string, handler fiber.Handler) {
+		a.server.Get(path, append(slices.Clone(middlewares), handler)...)
+	}
+
+	send := func(c *fiber.Ctx, yaml bool) error {
+		doc, err := a.apiSchema.document()
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(map[string]string{
+				"error": "Failed to generate OpenAPI schema",
+			})
+		}
+
+		body, tag, contentType := doc.json, doc.jsonETag, fiber.MIMEApplicationJSON
+		if yaml {
+			body, tag, contentType = doc.yaml, doc.yamlETag, "application/yaml"
+		}
+
+		if c.Get(fiber.HeaderIfNoneMatch) == tag {
+			return c.SendStatus(fiber.StatusNotModified)
+		}
+
+		c.Set(fiber.HeaderETag, tag)
+		// TODO: Add cache control based on environment
+		// c.Set("Cache-Control", "max-age=3600") // Cache for 1 hour
+		c.Set(fiber.HeaderContentType, contentType)
+
+		return c.Send(body)
+	}
+
+	get(cfg.JSONPath, func(c *fiber.Ctx) error {
+		return send(c, false)
+	})
+
+	get(cfg.YAMLPath, func(c *fiber.Ctx) error {
+		return send(c, true)
+	})
+
+	a.serveDocs(cfg, get)
+}
@@ -0,0 +1,110 @@
+package fast
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Reasons the OpenAPI schema of an app can not be exported
+var (
+	ErrOpenAPINotEnabled = errors.New("OpenAPI schema not enabled, use WithOpenAPI")
+	ErrOpenAPIOutdated   = errors.New("OpenAPI schema is outdated")
+)
+
+// OpenAPI returns the OpenAPI schema of the registered handlers without starting the server
+func (a App) OpenAPI() (*OpenAPISchema, error) {
+	if a.apiSchema == nil {
+		return nil, ErrOpenAPINotEnabled
+	}
+
+	return a.apiSchema.GenerateSchema()
+}
+
+// WriteOpenAPI writes the OpenAPI schema to the given file, as YAML when
+// its extension is .yaml or .yml and as JSON otherwise. The output is the
+// document served by the app, with stable key order, so it can be committed.
+func (a App) WriteOpenAPI(name string) error {
+	data, err := a.openAPIFile(name)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(name, data, 0o644)
+}
+
+// CheckOpenAPI returns ErrOpenAPIOutdated when the file written by WriteOpenAPI
+// differs from the current schema, e.g. from a test that gates API changes
+//
+//	func TestOpenAPI(t *testing.T) {
+//		if os.Getenv("UPDATE_OPENAPI") != "" {
+//			app.WriteOpenAPI("openapi.json")
+//		}
+//
+//		if err := app.CheckOpenAPI("openapi.json"); err != nil {
+//			t.Fatal(err)
+//		}
+//	}
+func (a App) CheckOpenAPI(name string) error {
+	want, err := a.openAPIFile(name)
+	if err != nil {
+		return err
+	}
+
+	got, err := os.ReadFile(name)
+	if err != nil {
+		return err
+	}
+
+	// Editors may add or strip the final newline
+	want, got = bytes.TrimSpace(want), bytes.TrimSpace(got)
+	if bytes.Equal(want, got) {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s differs %s", ErrOpenAPIOutdated, name, firstDifference(got, want))
+}
+
+// openAPIFile returns the document in the format of the file extension
+func (a App) openAPIFile(name string) ([]byte, error) {
+	if a.apiSchema == nil {
+		return nil, ErrOpenAPINotEnabled
+	}
+
+	doc, err := a.apiSchema.document()
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml":
+		return doc.yaml, nil
+	default:
+		return append(bytes.Clone(doc.json), '\n'), nil
+	}
+}
+
+// firstDifference describes the first line that differs between two documents
+func firstDifference(got, want []byte) string {
+	gotLines := strings.Split(string(got), "\n")
+	wantLines := strings.Split(string(want), "\n")
+
+	for idx := range max(len(gotLines), len(wantLines)) {
+		var gotLine, wantLine string
+		if idx < len(gotLines) {
+			gotLine = gotLines[idx]
+		}
+		if idx < len(wantLines) {
+			wantLine = wantLines[idx]
+		}
+
+		if gotLine != wantLine {
+			return fmt.Sprintf("at line %d: got %q, want %q", idx+1, strings.TrimSpace(gotLine), strings.TrimSpace(wantLine))
+		}
+	}
+
+	return ""
+}
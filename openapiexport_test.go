@@ -0,0 +1,93 @@
+package fast
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+type pet struct {
+	Name string   `json:"name"`
+	Tags []string `json:"tags"`
+}
+
+type petHandler struct{}
+
+func (petHandler) HandleGet() Handler {
+	return Endpoint[In, pet]().
+		Path("/:id").
+		Handle(func(c *Context, in In) (pet, error) {
+			return pet{}, nil
+		})
+}
+
+func TestOpenAPIReturnsACopy(t *testing.T) {
+	app := newTestApp(t,
+		WithExperimentalOpenAPISchema(),
+		WithSecurityScheme("apiKey", APIKeySecurity("header", "X-API-Key")),
+	)
+	app.MustRegister("/pets", petHandler{})
+
+	first, err := app.OpenAPI()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := json.Marshal(first)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first.Components.Schemas["pet"].Properties["name"] = SchemaObject{Type: "integer"}
+	first.Components.Schemas["injected"] = SchemaObject{Type: "string"}
+	first.Components.SecuritySchemes["injected"] = BasicSecurity()
+
+	second, err := app.OpenAPI()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := json.Marshal(second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(got) != string(want) {
+		t.Errorf("changing a generated schema changed the next one:\n got %s\nwant %s", got, want)
+	}
+}
+
+func TestOpenAPIConcurrentGeneration(t *testing.T) {
+	app := newTestApp(t, WithExperimentalOpenAPISchema())
+	app.MustRegister("/pets", petHandler{})
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+			if _, err := app.apiSchema.GenerateJSON(); err != nil {
+				t.Error(err)
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+			resp, err := app.server.Test(httptest.NewRequest(http.MethodGet, "/swagger.json", nil))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
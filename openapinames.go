@@ -0,0 +1,139 @@
+package fast
+
+import (
+	"log"
+	"path"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// OpenAPINamer is implemented by types that choose their OpenAPI component name
+//
+//	func (Pet) OpenAPIName() string { return "StorePet" }
+type OpenAPINamer interface {
+	OpenAPIName() string
+}
+
+var (
+	openAPINamerType = reflect.TypeOf((*OpenAPINamer)(nil)).Elem()
+
+	// packageQualifier matches the package path of the type names in generic instantiations
+	packageQualifier = regexp.MustCompile(`([\w.\-]+/)*[\w\-]+\.`)
+	// nameSeparator splits type names into words
+	nameSeparator = regexp.MustCompile(`[^A-Za-z0-9]+`)
+	// invalidNameChars matches the characters that are not allowed in component names
+	invalidNameChars = regexp.MustCompile(`[^A-Za-z0-9._\-]+`)
+)
+
+// componentName returns the unique name of a type in components.schemas,
+// or an empty string when the type is documented inline. Names are assigned
+// in registration order, so the same handlers always produce the same names.
+// Types with a taken name are prefixed with their package name, and then numbered.
+func (g *OpenAPIGenerator) componentName(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+
+	base := typeComponentName(t)
+	if base == "" {
+		return ""
+	}
+
+	name := base
+	if _, taken := g.taken[name]; taken {
+		name = toTitleCase(path.Base(t.PkgPath())) + base
+		name = nameSeparator.ReplaceAllString(name, "")
+
+		for idx := 2; ; idx++ {
+			if _, taken := g.taken[name]; !taken {
+				break
+			}
+			name = base + strconv.Itoa(idx)
+		}
+
+		log.Printf("OpenAPI schema name %s of %s is already used, using %s", base, t, name)
+	}
+
+	g.names[t] = name
+	g.taken[name] = t
+
+	return name
+}
+
+// bodyComponentName returns the unique name of the request body of an input
+// type with bound fields, its component name followed by Body, e.g. AccountBody
+func (g *OpenAPIGenerator) bodyComponentName(t reflect.Type, name string) string {
+	if bodyName, ok := g.bodyNames[t]; ok {
+		return bodyName
+	}
+
+	bodyName := name + "Body"
+	for idx := 2; ; idx++ {
+		if _, taken := g.taken[bodyName]; !taken {
+			break
+		}
+		bodyName = name + "Body" + strconv.Itoa(idx)
+	}
+
+	g.bodyNames[t] = bodyName
+	g.reserveName(bodyName)
+
+	return bodyName
+}
+
+// reserveName keeps a component name for a schema that is not a Go type
+func (g *OpenAPIGenerator) reserveName(name string) {
+	g.taken[name] = nil
+}
+
+// typeComponentName returns the component name of a type before collisions
+// are resolved, e.g. Page[github.com/acme/models.User] becomes PageUser
+func typeComponentName(t reflect.Type) string {
+	if t == reflect.TypeFor[In]() || t == reflect.TypeFor[Out]() {
+		return ""
+	}
+
+	if t.Kind() == reflect.Interface {
+		return ""
+	}
+
+	if t.Implements(openAPINamerType) {
+		return sanitizeComponentName(reflect.Zero(t).Interface().(OpenAPINamer).OpenAPIName())
+	}
+
+	if reflect.PointerTo(t).Implements(openAPINamerType) {
+		return sanitizeComponentName(reflect.New(t).Interface().(OpenAPINamer).OpenAPIName())
+	}
+
+	if t.Name() == "" {
+		return ""
+	}
+
+	name := t.Name()
+	if !strings.Contains(name, "[") {
+		return name
+	}
+
+	// Generic instantiations join the type arguments without their packages
+	name = packageQualifier.ReplaceAllString(name, "")
+
+	var result strings.Builder
+	for _, word := range nameSeparator.Split(name, -1) {
+		if word == "" {
+			continue
+		}
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		result.WriteString(string(runes))
+	}
+
+	return result.String()
+}
+
+// sanitizeComponentName removes the characters not allowed in component names
+func sanitizeComponentName(name string) string {
+	return invalidNameChars.ReplaceAllString(name, "")
+}
@@ -0,0 +1,322 @@
+package fast
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// pathParam is a parameter of a route path
+type pathParam struct {
+	// name is the name used by Context.Params, e.g. id or *1
+	name string
+	// template is the name used in the OpenAPI path template
+	template string
+	optional bool
+	schema   SchemaObject
+}
+
+// openAPIPath converts a Fiber route path to an OpenAPI path template, e.g.
+// "/users/:id<int>/files/*" becomes "/users/{id}/files/{wildcard}".
+// Wildcards are named wildcard and plus parameters path, followed by their index.
+func openAPIPath(route string) (string, []pathParam) {
+	var (
+		template strings.Builder
+		params   []pathParam
+	)
+
+	for idx := 0; idx < len(route); {
+		ch := route[idx]
+
+		switch {
+		case ch == '\\' && idx+1 < len(route):
+			// Escaped characters are matched literally
+			template.WriteByte(route[idx+1])
+			idx += 2
+
+		case ch == ':' && idx+1 < len(route) && isParamChar(route[idx+1]):
+			end := idx + 1
+			for end < len(route) && isParamChar(route[end]) {
+				end++
+			}
+
+			param := pathParam{
+				name:     route[idx+1 : end],
+				template: route[idx+1 : end],
+				schema:   SchemaObject{Type: "string"},
+			}
+
+			if end < len(route) && route[end] == '<' {
+				if closing := strings.IndexByte(route[end:], '>'); closing > 0 {
+					param.schema = constraintSchema(route[end+1 : end+closing])
+					end += closing + 1
+				}
+			}
+
+			if end < len(route) && route[end] == '?' {
+				param.optional = true
+				end++
+			}
+
+			params = append(params, param)
+			template.WriteString("{" + param.template + "}")
+			idx = end
+
+		case ch == '*' || ch == '+':
+			end := idx + 1
+			for end < len(route) && route[end] >= '0' && route[end] <= '9' {
+				end++
+			}
+
+			name := "wildcard"
+			if ch == '+' {
+				name = "path"
+			}
+
+			param := pathParam{
+				name:     route[idx:end],
+				template: name + route[idx+1:end],
+				optional: ch == '*',
+				schema:   SchemaObject{Type: "string"},
+			}
+
+			params = append(params, param)
+			template.WriteString("{" + param.template + "}")
+			idx = end
+
+		default:
+			template.WriteByte(ch)
+			idx++
+		}
+	}
+
+	return template.String(), params
+}
+
+// operationIDSeparator matches the characters not allowed in default operation ids
+var operationIDSeparator = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// operationID returns the default operation id of a route from its
+// OpenAPI path template, e.g. get and /users/{id} become get_users_id
+func operationID(method, template string) string {
+	id := operationIDSeparator.ReplaceAllString(method+template, "_")
+	return strings.TrimRight(id, "_")
+}
+
+// isParamChar reports whether a character can be part of a parameter name
+func isParamChar(ch byte) bool {
+	return ch == '_' ||
+		(ch >= 'a' && ch <= 'z') ||
+		(ch >= 'A' && ch <= 'Z') ||
+		(ch >= '0' && ch <= '9')
+}
+
+// constraintSchema returns the schema of a Fiber route constraint, e.g. int;min(1)
+func constraintSchema(constraints string) SchemaObject {
+	schema := SchemaObject{Type: "string"}
+
+	for _, constraint := range strings.Split(constraints, ";") {
+		name, param, _ := strings.Cut(strings.TrimSuffix(constraint, ")"), "(")
+
+		switch name {
+		case "int":
+			schema.Type = "integer"
+		case "bool":
+			schema.Type = "boolean"
+		case "float":
+			schema.Type = "number"
+		case "guid":
+			schema.Format = "uuid"
+		case "alpha":
+			schema.Pattern = "^[a-zA-Z]+$"
+		case "regex":
+			schema.Pattern = param
+		case "min", "minLen":
+			applyValidationRule(&schema, schemaKind(schema), "min", param)
+		case "max", "maxLen":
+			applyValidationRule(&schema, schemaKind(schema), "max", param)
+		case "len":
+			applyValidationRule(&schema, schemaKind(schema), "len", param)
+		case "range":
+			low, high, _ := strings.Cut(param, ",")
+			applyValidationRule(&schema, schemaKind(schema), "min", low)
+			applyValidationRule(&schema, schemaKind(schema), "max", high)
+		}
+	}
+
+	return schema
+}
+
+// schemaKind returns a Go type matching the type of a schema, to apply bounds
+func schemaKind(schema SchemaObject) reflect.Type {
+	switch schema.Type {
+	case "integer":
+		return reflect.TypeFor[int]()
+	case "number":
+		return reflect.TypeFor[float64]()
+	default:
+		return reflect.TypeFor[string]()
+	}
+}
+
+// generateParameters documents the path parameters of a route and the
+// header, cookie and query fields of its input. Untagged fields are
+// query parameters named after the Go field only when the request has
+// no body, like in the binder.
+func (g *OpenAPIGenerator) generateParameters(pathParams []pathParam, t reflect.Type, hasBody bool) []ParameterObject {
+	parameters := make([]ParameterObject, 0, len(pathParams))
+
+	var fields []reflect.StructField
+	if t != nil && t.Kind() == reflect.Struct {
+		for idx := range t.NumField() {
+			if field := t.Field(idx); field.IsExported() {
+				fields = append(fields, field)
+			}
+		}
+	}
+
+	for _, param := range pathParams {
+		parameter := ParameterObject{
+			Name:     param.template,
+			In:       sourcePath,
+			Required: true,
+			Schema:   param.schema,
+		}
+
+		if param.optional {
+			parameter.Description = "Optional, can be empty"
+		}
+
+		for _, field := range fields {
+			source, name, explicit := fieldBinding(field)
+			if explicit && source == sourcePath && name == param.name {
+				parameter.Schema = g.generateSchemaForType(field.Type)
+				applyValidationRules(&parameter.Schema, field.Type, field.Tag.Get("validate"))
+				parameter.Description, parameter.Example = fieldDocs(field)
+			}
+		}
+
+		parameters = append(parameters, parameter)
+	}
+
+	for _, field := range fields {
+		source, name, explicit := fieldBinding(field)
+
+		switch {
+		case source == sourcePath:
+			continue
+		case !explicit && (hasBody || !isBindable(field.Type) || field.Tag.Get("json") == "-"):
+			continue
+		}
+
+		parameter := ParameterObject{
+			Name:   name,
+			In:     source,
+			Schema: g.generateSchemaForType(field.Type),
+		}
+		parameter.Required = applyValidationRules(&parameter.Schema, field.Type, field.Tag.Get("validate"))
+		parameter.Description, parameter.Example = fieldDocs(field)
+
+		parameters = append(parameters, parameter)
+	}
+
+	if len(parameters) == 0 {
+		return nil
+	}
+
+	return parameters
+}
+
+// generateBodySchema returns the schema of the request body of an input,
+// without the fields bound from the path, headers, cookies and query string.
+// It reports whether any field was left out, and false when no field is
+// left for the body.
+func (g *OpenAPIGenerator) generateBodySchema(t reflect.Type) (schema SchemaObject, stripped, ok bool) {
+	if t.Kind() != reflect.Struct {
+		return g.generateSchemaForType(t), false, true
+	}
+
+	schema = g.structSchema(t)
+
+	for idx := range t.NumField() {
+		field := t.Field(idx)
+		if _, _, explicit := fieldBinding(field); !explicit {
+			continue
+		}
+
+		name := jsonFieldName(field)
+		if _, exists := schema.Properties[name]; !exists {
+			continue
+		}
+
+		stripped = true
+		delete(schema.Properties, name)
+		schema.Required = slices.DeleteFunc(schema.Required, func(required string) bool {
+			return required == name
+		})
+	}
+
+	if len(schema.Required) == 0 {
+		schema.Required = nil
+	}
+
+	return schema, stripped, len(schema.Properties) > 0
+}
+
+// requestBodySchema returns the schema of the request body of an input.
+// Inputs with bound fields reference a separate component without them,
+// e.g. AccountBody, so the component of the input type keeps every field
+// when the type is also used as output.
+// It reports false when the input has no body or is not a named type.
+func (g *OpenAPIGenerator) requestBodySchema(t reflect.Type) (SchemaObject, bool) {
+	schema, stripped, ok := g.generateBodySchema(t)
+	name := g.componentName(t)
+	if !ok || name == "" {
+		return SchemaObject{}, false
+	}
+
+	if stripped {
+		name = g.bodyComponentName(t, name)
+	}
+
+	g.schemas[name] = schema
+
+	return SchemaObject{Ref: componentRef(name)}, true
+}
+
+// bodyExample returns the example of a request body without the bound fields
+func bodyExample(t reflect.Type, example any) any {
+	if example == nil || t.Kind() != reflect.Struct {
+		return example
+	}
+
+	data, err := json.Marshal(example)
+	if err != nil {
+		return example
+	}
+
+	var body map[string]any
+	if err := json.Unmarshal(data, &body); err != nil {
+		return example
+	}
+
+	for idx := range t.NumField() {
+		if _, _, explicit := fieldBinding(t.Field(idx)); explicit {
+			delete(body, jsonFieldName(t.Field(idx)))
+		}
+	}
+
+	return body
+}
+
+// jsonFieldName returns the name of a field in the JSON encoding of its struct
+func jsonFieldName(field reflect.StructField) string {
+	name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+	if name == "" {
+		return field.Name
+	}
+
+	return name
+}
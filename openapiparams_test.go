@@ -0,0 +1,116 @@
+package fast
+
+import (
+	"maps"
+	"net/http"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestOpenAPIPathOperationID(t *testing.T) {
+	tests := []struct {
+		route        string
+		wantTemplate string
+		wantID       string
+	}{
+		{route: "/", wantTemplate: "/", wantID: "get"},
+		{route: "/users", wantTemplate: "/users", wantID: "get_users"},
+		{route: "/users/:id", wantTemplate: "/users/{id}", wantID: "get_users_id"},
+		{route: "/p/b/:id<int>/*", wantTemplate: "/p/b/{id}/{wildcard}", wantID: "get_p_b_id_wildcard"},
+		{route: "/files/:name.:ext?", wantTemplate: "/files/{name}.{ext}", wantID: "get_files_name_ext"},
+		{route: "/api/v1/user-groups/+", wantTemplate: "/api/v1/user-groups/{path}", wantID: "get_api_v1_user_groups_path"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.route, func(t *testing.T) {
+			template, _ := openAPIPath(tt.route)
+			if template != tt.wantTemplate {
+				t.Errorf("template = %s, want %s", template, tt.wantTemplate)
+			}
+
+			if id := operationID("get", template); id != tt.wantID {
+				t.Errorf("operation id = %s, want %s", id, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestImplicitQueryParameterNames(t *testing.T) {
+	type In struct {
+		UserID int    `json:"user_id"`
+		Page   int    `query:"page"`
+		Search string `json:"q"`
+	}
+
+	g := NewOpenAPIGenerator(OpenAPIInfo{})
+
+	var names []string
+	for _, parameter := range g.generateParameters(nil, reflect.TypeFor[In](), false) {
+		names = append(names, parameter.Name)
+	}
+
+	if want := []string{"UserID", "page", "Search"}; !slices.Equal(names, want) {
+		t.Errorf("parameters = %v, want %v", names, want)
+	}
+}
+
+type bodyAccount struct {
+	ID   int    `path:"id" json:"id"`
+	Name string `json:"name"`
+}
+
+func TestRequestBodyKeepsInputComponent(t *testing.T) {
+	get := Endpoint[struct{}, bodyAccount]().
+		Method(http.MethodGet).
+		Path("/:id").
+		Handle(func(c *Context, in struct{}) (bodyAccount, error) {
+			return bodyAccount{}, nil
+		})
+
+	patch := Endpoint[bodyAccount, bodyAccount]().
+		Method(http.MethodPatch).
+		Path("/:id").
+		Handle(func(c *Context, in bodyAccount) (bodyAccount, error) {
+			return in, nil
+		})
+
+	// The components must not depend on the registration order
+	orders := map[string][]Handler{
+		"output first": {get, patch},
+		"input first":  {patch, get},
+	}
+
+	for name, handlers := range orders {
+		t.Run(name, func(t *testing.T) {
+			g := NewOpenAPIGenerator(OpenAPIInfo{})
+			for _, handler := range handlers {
+				g.RegisterHandler("/accounts", handler)
+			}
+
+			schema, err := g.GenerateSchema()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			account := schema.Components.Schemas["bodyAccount"]
+			if want := []string{"id", "name"}; !slices.Equal(slices.Sorted(maps.Keys(account.Properties)), want) {
+				t.Errorf("bodyAccount properties = %v, want %v", slices.Sorted(maps.Keys(account.Properties)), want)
+			}
+
+			body := schema.Components.Schemas["bodyAccountBody"]
+			if want := []string{"name"}; !slices.Equal(slices.Sorted(maps.Keys(body.Properties)), want) {
+				t.Errorf("bodyAccountBody properties = %v, want %v", slices.Sorted(maps.Keys(body.Properties)), want)
+			}
+
+			operation := schema.Paths["/accounts/{id}"]["patch"]
+			if ref := operation.RequestBody.Content["application/json"].Schema.Ref; ref != componentRef("bodyAccountBody") {
+				t.Errorf("request body = %s", ref)
+			}
+
+			if ref := operation.Responses["200"].Content["application/json"].Schema.Ref; ref != componentRef("bodyAccount") {
+				t.Errorf("response = %s", ref)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package fast
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// validationFormats maps go-playground format tags to OpenAPI formats
+var validationFormats = map[string]string{
+	"email":    "email",
+	"uuid":     "uuid",
+	"uuid3":    "uuid",
+	"uuid4":    "uuid",
+	"uuid5":    "uuid",
+	"url":      "uri",
+	"http_url": "uri",
+	"uri":      "uri",
+	"ipv4":     "ipv4",
+	"ipv6":     "ipv6",
+	"hostname": "hostname",
+	"base64":   "byte",
+}
+
+// applyValidationRules maps the rules of a `validate` tag onto the schema
+// of a field and reports whether they make the field required.
+// Rules after `dive` apply to the items of slices and arrays.
+func applyValidationRules(schema *SchemaObject, t reflect.Type, tag string) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	rules := strings.Split(tag, ",")
+	required := false
+
+	for idx, rule := range rules {
+		// Alternatives can not be expressed with a single keyword
+		if strings.Contains(rule, "|") {
+			continue
+		}
+
+		name, param, _ := strings.Cut(rule, "=")
+
+		switch name {
+		case "required":
+			required = true
+		case "dive":
+			if schema.Items != nil && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+				applyValidationRules(schema.Items, t.Elem(), strings.Join(rules[idx+1:], ","))
+			}
+			return required
+		default:
+			applyValidationRule(schema, t, name, param)
+		}
+	}
+
+	return required
+}
+
+// applyValidationRule maps a single rule onto the matching JSON Schema keyword
+func applyValidationRule(schema *SchemaObject, t reflect.Type, name, param string) {
+	// Keywords next to a $ref are ignored
+	if schema.Ref != "" {
+		return
+	}
+
+	if format, ok := validationFormats[name]; ok {
+		schema.Format = format
+		return
+	}
+
+	switch name {
+	case "len":
+		setBound(schema, t, param, true, false)
+		setBound(schema, t, param, false, false)
+	case "min", "gte":
+		setBound(schema, t, param, true, false)
+	case "max", "lte":
+		setBound(schema, t, param, false, false)
+	case "gt":
+		setBound(schema, t, param, true, true)
+	case "lt":
+		setBound(schema, t, param, false, true)
+	case "oneof":
+		// Values encoded as strings are listed as they are sent
+		if schema.Type == "string" {
+			t = reflect.TypeFor[string]()
+		}
+		schema.Enum = enumValues(t, strings.Fields(param))
+	case "datetime":
+		schema.Format = datetimeFormat(param)
+	}
+}
+
+// setBound sets the lower or upper bound of a schema from a rule parameter.
+// Bounds are lengths for strings, item counts for slices and values for numbers.
+func setBound(schema *SchemaObject, t reflect.Type, param string, lower, exclusive bool) {
+	value, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		// e.g. gtfield or time comparisons against now
+		return
+	}
+
+	count := int(value)
+	if exclusive && lower {
+		count++
+	} else if exclusive {
+		count--
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		if lower {
+			schema.MinLength = &count
+		} else {
+			schema.MaxLength = &count
+		}
+	case reflect.Slice, reflect.Array:
+		if lower {
+			schema.MinItems = &count
+		} else {
+			schema.MaxItems = &count
+		}
+	case reflect.Map:
+		if lower {
+			schema.MinProperties = &count
+		} else {
+			schema.MaxProperties = &count
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		// Numbers encoded as strings, e.g. with the json string option, have no numeric bounds
+		if schema.Type != "integer" && schema.Type != "number" {
+			return
+		}
+
+		if lower {
+			schema.Minimum = &value
+			schema.ExclusiveMinimum = exclusive
+		} else {
+			schema.Maximum = &value
+			schema.ExclusiveMaximum = exclusive
+		}
+	}
+}
+
+// enumValues converts the values of a oneof rule to the type of the field
+func enumValues(t reflect.Type, values []string) []any {
+	enum := make([]any, 0, len(values))
+
+	for _, value := range values {
+		switch t.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			parsed, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil
+			}
+			enum = append(enum, parsed)
+		case reflect.Float32, reflect.Float64:
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil
+			}
+			enum = append(enum, parsed)
+		default:
+			enum = append(enum, value)
+		}
+	}
+
+	return enum
+}
+
+// datetimeFormat returns the format of a datetime rule from its Go layout
+func datetimeFormat(layout string) string {
+	hasDate := strings.Contains(layout, "2006")
+	hasTime := strings.Contains(layout, "15") || strings.Contains(layout, "04")
+
+	switch {
+	case hasDate && hasTime:
+		return "date-time"
+	case hasDate:
+		return "date"
+	default:
+		return "time"
+	}
+}
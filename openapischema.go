@@ -0,0 +1,285 @@
+package fast
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// schemaField is a field of the JSON encoding of a struct
+type schemaField struct {
+	name    string
+	field   reflect.StructField
+	depth   int
+	tagged  bool
+	options []string
+}
+
+// componentRef returns the reference to a schema in the components
+func componentRef(name string) string {
+	return "#/components/schemas/" + name
+}
+
+// generateSchemaForType generates an OpenAPI schema for a Go type.
+// Named structs are added to the components and referenced, so recursive
+// types like trees end in a $ref to the type being generated.
+// Well-known types and types implementing SchemaProvider use their own schema.
+func (g *OpenAPIGenerator) generateSchemaForType(t reflect.Type) SchemaObject {
+	if t == nil {
+		return SchemaObject{Type: "object"}
+	}
+
+	// Pointers can be null
+	if t.Kind() == reflect.Ptr {
+		return nullable(g.generateSchemaForType(t.Elem()))
+	}
+
+	if schema, ok := g.knownTypeSchema(t); ok {
+		return schema
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		name := g.componentName(t)
+		if name == "" {
+			return g.structSchema(t)
+		}
+
+		if _, exists := g.schemas[name]; !exists {
+			// The placeholder stops the recursion of self-referencing types
+			g.schemas[name] = SchemaObject{}
+			g.schemas[name] = g.structSchema(t)
+		}
+
+		return SchemaObject{Ref: componentRef(name)}
+
+	case reflect.Slice, reflect.Array:
+		// Byte slices are encoded as base64 strings
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			return SchemaObject{Type: "string", Format: "byte"}
+		}
+
+		items := g.generateSchemaForType(t.Elem())
+		return SchemaObject{
+			Type:  "array",
+			Items: &items,
+		}
+
+	case reflect.Map:
+		values := g.generateSchemaForType(t.Elem())
+		return SchemaObject{
+			Type:                 "object",
+			AdditionalProperties: &values,
+		}
+
+	case reflect.String:
+		return SchemaObject{Type: "string"}
+
+	case reflect.Bool:
+		return SchemaObject{Type: "boolean"}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return SchemaObject{Type: "integer"}
+
+	case reflect.Float32, reflect.Float64:
+		return SchemaObject{Type: "number"}
+
+	case reflect.Interface:
+		// Any value
+		return SchemaObject{}
+
+	default:
+		return SchemaObject{Type: "object"}
+	}
+}
+
+// structSchema generates the object schema of a struct with its JSON fields
+func (g *OpenAPIGenerator) structSchema(t reflect.Type) SchemaObject {
+	schema := SchemaObject{
+		Type:       "object",
+		Properties: make(map[string]SchemaObject),
+	}
+
+	for _, field := range jsonFields(t) {
+		fieldSchema := g.generateSchemaForType(field.field.Type)
+
+		// The string option encodes numbers and booleans as JSON strings
+		if slices.Contains(field.options, "string") && isQuotable(field.field.Type) {
+			fieldSchema = SchemaObject{Type: "string"}
+		}
+
+		validateRequired := applyValidationRules(&fieldSchema, field.field.Type, field.field.Tag.Get("validate"))
+		applyFieldDocs(&fieldSchema, field.field)
+		schema.Properties[field.name] = fieldSchema
+
+		// Fields are required if omitempty is NOT present or the validate tag requires them
+		if !slices.Contains(field.options, "omitempty") || validateRequired {
+			schema.Required = append(schema.Required, field.name)
+		}
+	}
+
+	return schema
+}
+
+// applyFieldDocs sets the description and example of a field schema
+// from its `doc` and `example` tags
+func applyFieldDocs(schema *SchemaObject, field reflect.StructField) {
+	description, example := fieldDocs(field)
+	if description == "" && example == nil {
+		return
+	}
+
+	// Keywords next to a $ref are ignored
+	if schema.Ref != "" {
+		*schema = SchemaObject{AllOf: []SchemaObject{*schema}}
+	}
+
+	schema.Description = description
+	schema.Example = example
+}
+
+// fieldDocs returns the `doc` and `example` tags of a field. Examples of
+// fields that are not strings are parsed as JSON, e.g. `example:"42"` or
+// `example:"[\"a\", \"b\"]"`, and kept as strings when they are not valid JSON.
+func fieldDocs(field reflect.StructField) (string, any) {
+	description := field.Tag.Get("doc")
+
+	raw, ok := field.Tag.Lookup("example")
+	if !ok {
+		return description, nil
+	}
+
+	fieldType := field.Type
+	for fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+
+	if fieldType.Kind() == reflect.String {
+		return description, raw
+	}
+
+	var example any
+	if err := json.Unmarshal([]byte(raw), &example); err != nil {
+		return description, raw
+	}
+
+	return description, example
+}
+
+// isQuotable reports whether the json string option applies to a type
+func isQuotable(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// jsonFields returns the fields of the JSON encoding of a struct. Fields of
+// embedded structs are promoted following the encoding/json rules: the
+// shallowest field wins, then the tagged one, and ambiguous fields are dropped.
+func jsonFields(t reflect.Type) []schemaField {
+	var all []schemaField
+	collectJSONFields(t, 0, make(map[reflect.Type]bool), &all)
+
+	var (
+		names  []string
+		byName = make(map[string][]schemaField)
+	)
+
+	for _, field := range all {
+		if _, exists := byName[field.name]; !exists {
+			names = append(names, field.name)
+		}
+		byName[field.name] = append(byName[field.name], field)
+	}
+
+	fields := make([]schemaField, 0, len(names))
+
+	for _, name := range names {
+		candidates := byName[name]
+
+		depth := candidates[0].depth
+		for _, candidate := range candidates {
+			depth = min(depth, candidate.depth)
+		}
+
+		candidates = slices.DeleteFunc(candidates, func(field schemaField) bool {
+			return field.depth != depth
+		})
+
+		if len(candidates) > 1 {
+			candidates = slices.DeleteFunc(candidates, func(field schemaField) bool {
+				return !field.tagged
+			})
+		}
+
+		if len(candidates) == 1 {
+			fields = append(fields, candidates[0])
+		}
+	}
+
+	return fields
+}
+
+// collectJSONFields appends the fields of a struct and of its embedded structs
+func collectJSONFields(t reflect.Type, depth int, visiting map[reflect.Type]bool, fields *[]schemaField) {
+	if visiting[t] {
+		return
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous {
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+
+			// Exported fields of embedded structs are promoted, even when the struct is not
+			if name == "" && fieldType.Kind() == reflect.Struct {
+				collectJSONFields(fieldType, depth+1, visiting, fields)
+				continue
+			}
+
+			if !field.IsExported() {
+				continue
+			}
+		} else if !field.IsExported() {
+			continue
+		}
+
+		tagged := name != ""
+		if !tagged {
+			name = field.Name
+		}
+
+		*fields = append(*fields, schemaField{
+			name:    name,
+			field:   field,
+			depth:   depth,
+			tagged:  tagged,
+			options: strings.Split(options, ","),
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package fast
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestStructSchemaStringOption(t *testing.T) {
+	type In struct {
+		Age   int     `json:"age,string" validate:"gt=0,lte=130"`
+		Level int     `json:"level,string" validate:"oneof=1 2 3"`
+		Score float64 `json:"score" validate:"gte=0"`
+	}
+
+	g := NewOpenAPIGenerator(OpenAPIInfo{})
+	schema := g.structSchema(reflect.TypeFor[In]())
+
+	tests := []struct {
+		property string
+		want     string
+	}{
+		{property: "age", want: `{"type":"string"}`},
+		{property: "level", want: `{"type":"string","enum":["1","2","3"]}`},
+		{property: "score", want: `{"type":"number","minimum":0}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.property, func(t *testing.T) {
+			got, err := json.Marshal(schema.Properties[tt.property])
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("schema = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
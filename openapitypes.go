@@ -0,0 +1,116 @@
+package fast
+
+import (
+	"database/sql"
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"time"
+)
+
+// SchemaProvider is implemented by types that document their own JSON schema,
+// e.g. a decimal type encoded as a string
+//
+//	func (Decimal) JSONSchema() fast.SchemaObject {
+//		return fast.SchemaObject{Type: "string", Format: "decimal"}
+//	}
+type SchemaProvider interface {
+	JSONSchema() SchemaObject
+}
+
+var (
+	schemaProviderType = reflect.TypeOf((*SchemaProvider)(nil)).Elem()
+	jsonMarshalerType  = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType  = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// wellKnownTypes returns the schemas of the standard library types
+// that are not encoded as their Go kind suggests
+func wellKnownTypes() map[reflect.Type]SchemaObject {
+	return map[reflect.Type]SchemaObject{
+		reflect.TypeFor[time.Time]():       {Type: "string", Format: "date-time"},
+		reflect.TypeFor[time.Duration]():   {Type: "integer", Format: "int64", Description: "Duration in nanoseconds"},
+		reflect.TypeFor[[]byte]():          {Type: "string", Format: "byte"},
+		reflect.TypeFor[json.RawMessage](): {},
+		reflect.TypeFor[json.Number]():     {Type: "number"},
+		reflect.TypeFor[sql.NullString]():  {Type: "string", Nullable: true},
+		reflect.TypeFor[sql.NullBool]():    {Type: "boolean", Nullable: true},
+		reflect.TypeFor[sql.NullByte]():    {Type: "integer", Nullable: true},
+		reflect.TypeFor[sql.NullInt16]():   {Type: "integer", Format: "int32", Nullable: true},
+		reflect.TypeFor[sql.NullInt32]():   {Type: "integer", Format: "int32", Nullable: true},
+		reflect.TypeFor[sql.NullInt64]():   {Type: "integer", Format: "int64", Nullable: true},
+		reflect.TypeFor[sql.NullFloat64](): {Type: "number", Format: "double", Nullable: true},
+		reflect.TypeFor[sql.NullTime]():    {Type: "string", Format: "date-time", Nullable: true},
+	}
+}
+
+// RegisterType sets the schema of a type, replacing the generated one
+func (g *OpenAPIGenerator) RegisterType(t reflect.Type, schema SchemaObject) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.cached = nil
+	g.types[t] = schema
+}
+
+// WithOpenAPIType sets the schema of T in the OpenAPI schema, for types
+// that can not implement SchemaProvider, e.g.
+//
+//	fast.WithOpenAPIType[decimal.Decimal](fast.SchemaObject{Type: "string", Format: "decimal"})
+func WithOpenAPIType[T any](schema SchemaObject) func(*App) {
+	return func(a *App) {
+		if a.openAPITypes == nil {
+			a.openAPITypes = make(map[reflect.Type]SchemaObject)
+		}
+
+		a.openAPITypes[reflect.TypeFor[T]()] = schema
+	}
+}
+
+// knownTypeSchema returns the schema of registered types, types implementing
+// SchemaProvider and types with their own JSON or text encoding
+func (g *OpenAPIGenerator) knownTypeSchema(t reflect.Type) (SchemaObject, bool) {
+	if schema, ok := g.types[t]; ok {
+		return schema, true
+	}
+
+	if t.Kind() == reflect.Interface {
+		return SchemaObject{}, false
+	}
+
+	switch {
+	case t.Implements(schemaProviderType):
+		return reflect.Zero(t).Interface().(SchemaProvider).JSONSchema(), true
+	case reflect.PointerTo(t).Implements(schemaProviderType):
+		return reflect.New(t).Interface().(SchemaProvider).JSONSchema(), true
+	case t.Implements(jsonMarshalerType), reflect.PointerTo(t).Implements(jsonMarshalerType):
+		// The encoding is unknown, so any value is accepted
+		return SchemaObject{}, true
+	case t.Implements(textMarshalerType), reflect.PointerTo(t).Implements(textMarshalerType):
+		// Encoded as a string, e.g. UUIDs and IP addresses
+		schema := SchemaObject{Type: "string"}
+		if t.Name() == "UUID" {
+			schema.Format = "uuid"
+		}
+		return schema, true
+	}
+
+	return SchemaObject{}, false
+}
+
+// nullable marks a schema as accepting null, wrapping references in allOf
+func nullable(schema SchemaObject) SchemaObject {
+	switch {
+	case schema.Ref != "":
+		return SchemaObject{
+			AllOf:    []SchemaObject{schema},
+			Nullable: true,
+		}
+	case schema.Type == "":
+		// Schemas without type already accept null
+		return schema
+	default:
+		schema.Nullable = true
+		return schema
+	}
+}
@@ -0,0 +1,221 @@
+package fast
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"io/fs"
+	"path"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Default locations of the documentation renderers
+const (
+	defaultSwaggerUIURL = "https://unpkg.com/swagger-ui-dist@4.5.0"
+	defaultRedocURL     = "https://cdn.jsdelivr.net/npm/redoc@2.1.5/bundles/redoc.standalone.js"
+	defaultScalarURL    = "https://cdn.jsdelivr.net/npm/@scalar/api-reference@1"
+)
+
+// DocsConfig configures the pages rendering the OpenAPI schema.
+// The pages load no inline scripts or styles, so they work under a strict
+// Content-Security-Policy when their assets are served by the app.
+type DocsConfig struct {
+	// SwaggerUIURL is the base URL of the swagger-ui-dist files,
+	// defaults to unpkg.com, or to the Assets when they are set
+	SwaggerUIURL string
+	// Assets are served at {UIPath}/assets, e.g. an embedded copy of swagger-ui-dist
+	// for air-gapped environments. The files are read from the root of the FS.
+	Assets fs.FS
+	// RedocPath serves Redoc when set, e.g. /redoc
+	RedocPath string
+	// RedocURL is the URL of the Redoc standalone bundle, defaults to jsDelivr
+	RedocURL string
+	// ScalarPath serves Scalar when set, e.g. /scalar
+	ScalarPath string
+	// ScalarURL is the URL of the Scalar API reference bundle, defaults to jsDelivr
+	ScalarURL string
+	// ExpandDepth is how many levels of the schemas Swagger UI and Redoc
+	// expand by default. Zero keeps their default, negative values collapse them.
+	ExpandDepth int
+	// PersistAuthorization keeps the credentials entered in Swagger UI
+	// and Scalar when the page is reloaded
+	PersistAuthorization bool
+}
+
+// serveDocs registers the Swagger UI, Redoc and Scalar pages and the assets
+func (a App) serveDocs(cfg OpenAPIConfig, get func(path string, handler fiber.Handler)) {
+	docs := cfg.Docs
+
+	if docs.Assets != nil {
+		assetsPath := path.Join(cfg.UIPath, "assets")
+		if docs.SwaggerUIURL == "" {
+			docs.SwaggerUIURL = assetsPath
+		}
+
+		get(assetsPath+"/*", func(c *fiber.Ctx) error {
+			name := c.Params("*")
+
+			data, err := fs.ReadFile(docs.Assets, name)
+			if err != nil {
+				return c.SendStatus(fiber.StatusNotFound)
+			}
+
+			c.Type(path.Ext(name))
+			return c.Send(data)
+		})
+	}
+
+	if docs.SwaggerUIURL == "" {
+		docs.SwaggerUIURL = defaultSwaggerUIURL
+	}
+
+	if docs.RedocURL == "" {
+		docs.RedocURL = defaultRedocURL
+	}
+
+	if docs.ScalarURL == "" {
+		docs.ScalarURL = defaultScalarURL
+	}
+
+	specURL := html.EscapeString(cfg.JSONPath)
+	initializerPath := path.Join(cfg.UIPath, "swagger-initializer.js")
+	stylePath := path.Join(cfg.UIPath, "index.css")
+
+	swaggerPage := fmt.Sprintf(swaggerUIHTML,
+		html.EscapeString(docs.SwaggerUIURL),
+		html.EscapeString(stylePath),
+		html.EscapeString(initializerPath),
+	)
+	swaggerInitializer := swaggerUIInitializer(cfg.JSONPath, docs)
+
+	get(cfg.UIPath, sendDocsPage(fiber.MIMETextHTMLCharsetUTF8, swaggerPage))
+	get(initializerPath, sendDocsPage(fiber.MIMEApplicationJavaScriptCharsetUTF8, swaggerInitializer))
+	get(stylePath, sendDocsPage("text/css; charset=utf-8", swaggerUICSS))
+
+	if docs.RedocPath != "" {
+		var expand string
+		if docs.ExpandDepth > 0 {
+			expand = fmt.Sprintf(` schema-expansion-level="%d"`, docs.ExpandDepth)
+		}
+
+		page := fmt.Sprintf(redocHTML, specURL, expand, html.EscapeString(docs.RedocURL))
+		get(docs.RedocPath, sendDocsPage(fiber.MIMETextHTMLCharsetUTF8, page))
+	}
+
+	if docs.ScalarPath != "" {
+		configuration, _ := json.Marshal(map[string]any{
+			"persistAuth": docs.PersistAuthorization,
+		})
+
+		page := fmt.Sprintf(scalarHTML, specURL, html.EscapeString(string(configuration)), html.EscapeString(docs.ScalarURL))
+		get(docs.ScalarPath, sendDocsPage(fiber.MIMETextHTMLCharsetUTF8, page))
+	}
+}
+
+// sendDocsPage returns a handler sending a prebuilt page or asset
+func sendDocsPage(contentType, body string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, contentType)
+		return c.SendString(body)
+	}
+}
+
+// swaggerUIInitializer returns the script starting Swagger UI
+func swaggerUIInitializer(specURL string, docs DocsConfig) string {
+	options := map[string]any{
+		"url":                  specURL,
+		"dom_id":               "#swagger-ui",
+		"deepLinking":          true,
+		"layout":               "StandaloneLayout",
+		"persistAuthorization": docs.PersistAuthorization,
+	}
+
+	switch {
+	case docs.ExpandDepth > 0:
+		options["defaultModelExpandDepth"] = docs.ExpandDepth
+		options["defaultModelsExpandDepth"] = docs.ExpandDepth
+	case docs.ExpandDepth < 0:
+		options["defaultModelExpandDepth"] = 0
+		options["defaultModelsExpandDepth"] = -1
+	}
+
+	encoded, _ := json.MarshalIndent(options, "    ", "    ")
+
+	return fmt.Sprintf(swaggerUIScript, encoded)
+}
+
+// swaggerUIHTML is the Swagger UI page, the placeholders are the
+// base URL of the assets, the style path and the initializer path
+const swaggerUIHTML = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <title>Swagger UI</title>
+    <link rel="stylesheet" type="text/css" href="%[1]s/swagger-ui.css" />
+    <link rel="stylesheet" type="text/css" href="%[2]s" />
+    <link rel="icon" type="image/png" href="%[1]s/favicon-32x32.png" sizes="32x32" />
+    <link rel="icon" type="image/png" href="%[1]s/favicon-16x16.png" sizes="16x16" />
+</head>
+
+<body>
+    <div id="swagger-ui"></div>
+
+    <script src="%[1]s/swagger-ui-bundle.js" charset="UTF-8"> </script>
+    <script src="%[1]s/swagger-ui-standalone-preset.js" charset="UTF-8"> </script>
+    <script src="%[3]s" charset="UTF-8"> </script>
+</body>
+</html>`
+
+// swaggerUIScript starts Swagger UI, the placeholder is the JSON of the options
+const swaggerUIScript = `window.onload = function () {
+    const options = %s;
+
+    window.ui = SwaggerUIBundle(Object.assign(options, {
+        presets: [
+            SwaggerUIBundle.presets.apis,
+            SwaggerUIStandalonePreset
+        ],
+        plugins: [
+            SwaggerUIBundle.plugins.DownloadUrl
+        ]
+    }));
+};
+`
+
+const swaggerUICSS = `html { box-sizing: border-box; overflow: -moz-scrollbars-vertical; overflow-y: scroll; }
+*, *:before, *:after { box-sizing: inherit; }
+body { margin: 0; background: #fafafa; }
+`
+
+// redocHTML is the Redoc page, the placeholders are the schema URL,
+// the extra attributes and the bundle URL
+const redocHTML = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <title>Redoc</title>
+    <meta name="viewport" content="width=device-width, initial-scale=1">
+</head>
+
+<body>
+    <redoc spec-url="%s"%s></redoc>
+    <script src="%s" charset="UTF-8"> </script>
+</body>
+</html>`
+
+// scalarHTML is the Scalar page, the placeholders are the schema URL,
+// the configuration and the bundle URL
+const scalarHTML = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <title>Scalar</title>
+    <meta name="viewport" content="width=device-width, initial-scale=1">
+</head>
+
+<body>
+    <script id="api-reference" data-url="%s" data-configuration="%s"></script>
+    <script src="%s" charset="UTF-8"> </script>
+</body>
+</html>`
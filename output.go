@@ -0,0 +1,75 @@
+package fast
+
+import (
+	"log"
+	"math/rand/v2"
+)
+
+// OutputValidationMode is what happens when the output of an endpoint is invalid
+type OutputValidationMode int
+
+const (
+	// EnforceOutputValidation answers with 500 and the validation errors, the default
+	EnforceOutputValidation OutputValidationMode = iota
+	// LogOutputValidation reports the errors and still sends the output
+	LogOutputValidation
+	// SkipOutputValidation does not validate outputs
+	SkipOutputValidation
+)
+
+// OutputValidationPolicy configures the validation of endpoint outputs.
+// Outputs are validated only when they are structs with `validate` tags.
+//
+//	fast.WithOutputValidation(fast.OutputValidationPolicy{
+//		Mode:       fast.LogOutputValidation,
+//		SampleRate: 0.01,
+//		OnViolation: func(c *fast.Context, errs fast.FieldErrors) {
+//			logger.Warn("invalid output", "route", c.Route().Path, "errors", errs)
+//		},
+//	})
+type OutputValidationPolicy struct {
+	Mode OutputValidationMode
+	// SampleRate is the fraction of responses validated, from 0 to 1.
+	// Zero validates every response.
+	SampleRate float64
+	// OnViolation is called with the errors of every invalid output.
+	// Endpoint policies without it use the one of the app, which
+	// defaults to logging them with the standard logger.
+	OnViolation func(c *Context, errs FieldErrors)
+}
+
+// WithOutputValidation sets the output validation policy of every endpoint,
+// endpoints can override it with EndpointBuilder.OutputValidation
+func WithOutputValidation(policy OutputValidationPolicy) func(*App) {
+	return func(a *App) {
+		a.config.outputValidation = policy
+	}
+}
+
+// sampled reports whether the current response should be validated
+func (p OutputValidationPolicy) sampled() bool {
+	if p.SampleRate <= 0 || p.SampleRate >= 1 {
+		return true
+	}
+
+	return rand.Float64() < p.SampleRate //nolint:gosec
+}
+
+// inherit returns the policy with the unset hooks taken from the app policy
+func (p OutputValidationPolicy) inherit(app OutputValidationPolicy) OutputValidationPolicy {
+	if p.OnViolation == nil {
+		p.OnViolation = app.OnViolation
+	}
+
+	return p
+}
+
+// report hands the errors of an invalid output to OnViolation
+func (p OutputValidationPolicy) report(c *Context, errs FieldErrors) {
+	if p.OnViolation != nil {
+		p.OnViolation(c, errs)
+		return
+	}
+
+	log.Printf("invalid output in handler %s %s: %s", c.Method(), c.Route().Path, errs)
+}
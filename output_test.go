@@ -0,0 +1,41 @@
+package fast
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type invalidOut struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type invalidOutputHandler struct{}
+
+func (invalidOutputHandler) HandleGet() Handler {
+	return Endpoint[In, invalidOut]().
+		OutputValidation(OutputValidationPolicy{Mode: LogOutputValidation}).
+		Handle(func(c *Context, in In) (invalidOut, error) {
+			return invalidOut{}, nil
+		})
+}
+
+func TestOutputValidationInheritsOnViolation(t *testing.T) {
+	var violations int
+
+	app := newTestApp(t, WithOutputValidation(OutputValidationPolicy{
+		OnViolation: func(c *Context, errs FieldErrors) {
+			violations++
+		},
+	}))
+	app.MustRegister("/out", invalidOutputHandler{})
+
+	status, body := send(t, app, httptest.NewRequest(http.MethodGet, "/out", nil))
+	if status != http.StatusOK {
+		t.Fatalf("status = %d, want %d in log mode: %s", status, http.StatusOK, body)
+	}
+
+	if violations != 1 {
+		t.Errorf("app OnViolation called %d times, want 1", violations)
+	}
+}
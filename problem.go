@@ -0,0 +1,110 @@
+package fast
+
+import (
+	"encoding/json"
+	"maps"
+	"net/http"
+
+	"github.com/esequiel378/fast/internal/validator"
+	"github.com/gofiber/fiber/v2"
+)
+
+// MIMEApplicationProblemJSON is the content type of problem details responses
+const MIMEApplicationProblemJSON = "application/problem+json"
+
+// ProblemDetails is an RFC 9457 problem details object.
+// Extensions are serialized as members of the object itself.
+type ProblemDetails struct {
+	Type       string         `json:"type,omitempty"`
+	Title      string         `json:"title,omitempty"`
+	Status     int            `json:"status,omitempty"`
+	Detail     string         `json:"detail,omitempty"`
+	Instance   string         `json:"instance,omitempty"`
+	Extensions map[string]any `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler, flattening the extension members
+func (p ProblemDetails) MarshalJSON() ([]byte, error) {
+	members := make(map[string]any, len(p.Extensions)+5)
+	maps.Copy(members, p.Extensions)
+
+	// The standard members always win over extensions with the same name
+	for name, value := range map[string]any{
+		"type":     p.Type,
+		"title":    p.Title,
+		"status":   p.Status,
+		"detail":   p.Detail,
+		"instance": p.Instance,
+	} {
+		if value != "" && value != 0 {
+			members[name] = value
+		}
+	}
+
+	return json.Marshal(members)
+}
+
+// WithProblemDetails renders every error response as an RFC 9457
+// application/problem+json document. Validation failures carry the
+// list of field errors in the `errors` extension member.
+// Unrouted requests are rendered too, unless the fiber app is set with WithFiberApp.
+func WithProblemDetails() func(*App) {
+	return func(a *App) {
+		a.config.problemDetails = true
+	}
+}
+
+// newProblem returns the problem for the given status and the current request
+func newProblem(c *fiber.Ctx, status int, detail string) ProblemDetails {
+	return ProblemDetails{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: c.Path(),
+	}
+}
+
+// newHTTPErrorProblem returns the problem for an HTTPError,
+// its code and details are added as extension members
+func newHTTPErrorProblem(c *fiber.Ctx, httpErr *HTTPError) ProblemDetails {
+	problem := newProblem(c, httpErr.Status, httpErr.Message)
+	problem.Extensions = make(map[string]any)
+
+	if httpErr.Code != "" {
+		problem.Extensions["code"] = httpErr.Code
+	}
+
+	if httpErr.Details != nil {
+		problem.Extensions["details"] = httpErr.Details
+	}
+
+	return problem
+}
+
+// newValidationProblem returns the problem for a list of field errors
+func newValidationProblem(c *fiber.Ctx, status int, errs []validator.Error) ProblemDetails {
+	detail := "The request input is invalid"
+	if status >= http.StatusInternalServerError {
+		detail = "The response output is invalid"
+	}
+
+	problem := newProblem(c, status, detail)
+	problem.Extensions = map[string]any{
+		"errors": errs,
+	}
+
+	return problem
+}
+
+// sendProblem writes the problem as the response
+func sendProblem(c *fiber.Ctx, problem ProblemDetails) error {
+	data, err := json.Marshal(problem)
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderContentType, MIMEApplicationProblemJSON)
+
+	return c.Status(problem.Status).Send(data)
+}
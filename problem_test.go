@@ -0,0 +1,145 @@
+package fast
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type problemIn struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type problemHandler struct{}
+
+func (problemHandler) HandleGet() Handler {
+	return Endpoint[struct{}, struct{}]().
+		Method(http.MethodGet).
+		Handle(func(c *Context, in struct{}) (struct{}, error) {
+			return struct{}{}, NotFoundError("account not found").WithCode("account_not_found")
+		})
+}
+
+func (problemHandler) HandleCreate() Handler {
+	return Endpoint[problemIn, problemIn]().
+		Method(http.MethodPost).
+		Path("/new").
+		Handle(func(c *Context, in problemIn) (problemIn, error) {
+			return in, nil
+		})
+}
+
+func TestProblemDetails(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        []func(*App)
+		method      string
+		target      string
+		body        string
+		wantStatus  int
+		wantType    string
+		wantMembers map[string]any
+	}{
+		{
+			name:        "unrouted",
+			opts:        []func(*App){WithProblemDetails()},
+			method:      http.MethodGet,
+			target:      "/nothere",
+			wantStatus:  http.StatusNotFound,
+			wantType:    MIMEApplicationProblemJSON,
+			wantMembers: map[string]any{"status": 404.0, "title": "Not Found", "instance": "/nothere"},
+		},
+		{
+			name:        "method not allowed",
+			opts:        []func(*App){WithProblemDetails()},
+			method:      http.MethodDelete,
+			target:      "/accounts",
+			wantStatus:  http.StatusMethodNotAllowed,
+			wantType:    MIMEApplicationProblemJSON,
+			wantMembers: map[string]any{"status": 405.0, "title": "Method Not Allowed"},
+		},
+		{
+			name:        "http error",
+			opts:        []func(*App){WithProblemDetails()},
+			method:      http.MethodGet,
+			target:      "/accounts",
+			wantStatus:  http.StatusNotFound,
+			wantType:    MIMEApplicationProblemJSON,
+			wantMembers: map[string]any{"detail": "account not found", "code": "account_not_found"},
+		},
+		{
+			name:        "validation",
+			opts:        []func(*App){WithProblemDetails()},
+			method:      http.MethodPost,
+			target:      "/accounts/new",
+			body:        `{}`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantType:    MIMEApplicationProblemJSON,
+			wantMembers: map[string]any{"detail": "The request input is invalid", "instance": "/accounts/new"},
+		},
+		{
+			name:       "unrouted without problem details",
+			method:     http.MethodGet,
+			target:     "/nothere",
+			wantStatus: http.StatusNotFound,
+			wantType:   fiber.MIMETextPlainCharsetUTF8,
+		},
+		{
+			name:       "unrouted with a custom fiber app",
+			opts:       []func(*App){WithFiberApp(fiber.New()), WithProblemDetails()},
+			method:     http.MethodGet,
+			target:     "/nothere",
+			wantStatus: http.StatusNotFound,
+			wantType:   fiber.MIMETextPlainCharsetUTF8,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestApp(t, tt.opts...)
+			app.MustRegister("/accounts", problemHandler{})
+
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.server.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", resp.StatusCode, tt.wantStatus, body)
+			}
+
+			if contentType := resp.Header.Get("Content-Type"); contentType != tt.wantType {
+				t.Errorf("content type = %s, want %s", contentType, tt.wantType)
+			}
+
+			if len(tt.wantMembers) == 0 {
+				return
+			}
+
+			var members map[string]any
+			if err := json.Unmarshal(body, &members); err != nil {
+				t.Fatalf("invalid problem %s: %s", body, err)
+			}
+
+			for name, want := range tt.wantMembers {
+				if members[name] != want {
+					t.Errorf("%s = %v, want %v", name, members[name], want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package fast
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RawEndpointBuilder is the builder for creating raw endpoints.
+// Raw endpoints have no input nor output, the handler gets the plain
+// Context and is responsible for reading the request and writing the response.
+type RawEndpointBuilder struct {
+	path        string
+	method      string
+	docs        endpointDocs
+	errors      []ErrorResponse
+	middlewares []func(*Context) error
+}
+
+// RawEndpoint creates a new raw endpoint builder
+func RawEndpoint() *RawEndpointBuilder {
+	return &RawEndpointBuilder{
+		path:   "/",
+		method: http.MethodGet,
+	}
+}
+
+// Path sets the path of the endpoint
+func (b *RawEndpointBuilder) Path(path string) *RawEndpointBuilder {
+	b.path = path
+	return b
+}
+
+// Method sets the method of the endpoint
+func (b *RawEndpointBuilder) Method(method string) *RawEndpointBuilder {
+	b.method = method
+	return b
+}
+
+// Summary sets the short summary of the endpoint in the OpenAPI schema
+func (b *RawEndpointBuilder) Summary(summary string) *RawEndpointBuilder {
+	b.docs.summary = summary
+	return b
+}
+
+// Description sets the description of the endpoint in the OpenAPI schema, it can use Markdown
+func (b *RawEndpointBuilder) Description(description string) *RawEndpointBuilder {
+	b.docs.description = description
+	return b
+}
+
+// Tags sets the tags of the endpoint in the OpenAPI schema,
+// replacing the ones guessed from the path
+func (b *RawEndpointBuilder) Tags(tags ...string) *RawEndpointBuilder {
+	b.docs.tags = tags
+	return b
+}
+
+// OperationID sets the unique operation id of the endpoint in the OpenAPI schema
+func (b *RawEndpointBuilder) OperationID(id string) *RawEndpointBuilder {
+	b.docs.operationID = id
+	return b
+}
+
+// Deprecated marks the endpoint as deprecated in the OpenAPI schema
+func (b *RawEndpointBuilder) Deprecated() *RawEndpointBuilder {
+	b.docs.deprecated = true
+	return b
+}
+
+// Errors declares the error responses the endpoint can send, see EndpointBuilder.Errors
+func (b *RawEndpointBuilder) Errors(responses ...ErrorResponse) *RawEndpointBuilder {
+	b.errors = append(b.errors, responses...)
+	return b
+}
+
+// Security documents that the endpoint requires the given security scheme,
+// registered with WithSecurityScheme. Calling it again requires both schemes.
+func (b *RawEndpointBuilder) Security(scheme string, scopes ...string) *RawEndpointBuilder {
+	b.docs.security = b.docs.security.with(scheme, scopes)
+	return b
+}
+
+// Middlewares sets the middlewares of the endpoint
+func (b *RawEndpointBuilder) Middlewares(middlewares ...func(*Context) error) *RawEndpointBuilder {
+	b.middlewares = middlewares
+	return b
+}
+
+// Handle finalizes the builder and returns a Handler that can be registered
+func (b *RawEndpointBuilder) Handle(fn func(*Context) error) Handler {
+	return &rawHandler{
+		path:        b.path,
+		method:      b.method,
+		docs:        b.docs,
+		errors:      b.errors,
+		handler:     fn,
+		middlewares: b.middlewares,
+	}
+}
+
+// rawHandler implements the Handler interface without touching the request body
+type rawHandler struct {
+	path        string
+	method      string
+	docs        endpointDocs
+	errors      []ErrorResponse
+	handler     func(*Context) error
+	middlewares []func(*Context) error
+}
+
+// Path returns the endpoint path
+func (h *rawHandler) Path() string {
+	return h.path
+}
+
+// Method returns the HTTP method
+func (h *rawHandler) Method() string {
+	return h.method
+}
+
+// Middlewares returns the middleware functions
+func (h *rawHandler) Middlewares() []func(*Context) error {
+	return h.middlewares
+}
+
+// Register registers the endpoint to the given router
+func (h *rawHandler) Register(r fiber.Router, cfg *config, middlewares ...Middleware) {
+	handlers := wrapMiddlewares(cfg, append(middlewares, h.middlewares...))
+
+	handlers = append(handlers, func(c *fiber.Ctx) error {
+		if err := h.handler(newContext(c, cfg)); err != nil {
+			sendErr := cfg.handleError(c, err)
+			if cfg.development {
+				checkDeclaredStatus(c, h.errors)
+			}
+			return sendErr
+		}
+
+		return nil
+	})
+
+	r.Add(h.method, h.path, handlers...)
+}
+
+// InputSerializer returns nil, raw endpoints have no input
+func (h *rawHandler) InputSerializer() any {
+	return nil
+}
+
+// OutputSerializer returns nil, raw endpoints have no output
+func (h *rawHandler) OutputSerializer() any {
+	return nil
+}
+
+func (h *rawHandler) meta() endpointMeta {
+	return endpointMeta{
+		docs:   h.docs,
+		errors: h.errors,
+	}
+}
@@ -0,0 +1,100 @@
+package fast
+
+import (
+	"log"
+	"net/http"
+	"reflect"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ErrorResponse is an error response an endpoint declares it can send.
+// It is documented in the OpenAPI schema with the schema of its body.
+type ErrorResponse struct {
+	// Status is the HTTP status code of the response
+	Status int
+	// Description defaults to the status text
+	Description string
+	// body is the type of the response body
+	body reflect.Type
+}
+
+// Describe returns a copy of the response with the given description
+func (r ErrorResponse) Describe(description string) ErrorResponse {
+	r.Description = description
+	return r
+}
+
+// E declares an error response with the given status and body type.
+// Use HTTPError as the body of the errors returned with NewHTTPError
+// and its constructors, e.g. fast.E[fast.HTTPError](http.StatusConflict).
+func E[T any](status int) ErrorResponse {
+	return ErrorResponse{
+		Status:      status,
+		Description: http.StatusText(status),
+		body:        reflect.TypeFor[T](),
+	}
+}
+
+// E400 declares a 400 Bad Request response
+func E400[T any]() ErrorResponse { return E[T](http.StatusBadRequest) }
+
+// E401 declares a 401 Unauthorized response
+func E401[T any]() ErrorResponse { return E[T](http.StatusUnauthorized) }
+
+// E403 declares a 403 Forbidden response
+func E403[T any]() ErrorResponse { return E[T](http.StatusForbidden) }
+
+// E404 declares a 404 Not Found response
+func E404[T any]() ErrorResponse { return E[T](http.StatusNotFound) }
+
+// E409 declares a 409 Conflict response
+func E409[T any]() ErrorResponse { return E[T](http.StatusConflict) }
+
+// E410 declares a 410 Gone response
+func E410[T any]() ErrorResponse { return E[T](http.StatusGone) }
+
+// E412 declares a 412 Precondition Failed response
+func E412[T any]() ErrorResponse { return E[T](http.StatusPreconditionFailed) }
+
+// E422 declares a 422 Unprocessable Entity response
+func E422[T any]() ErrorResponse { return E[T](http.StatusUnprocessableEntity) }
+
+// E429 declares a 429 Too Many Requests response
+func E429[T any]() ErrorResponse { return E[T](http.StatusTooManyRequests) }
+
+// E500 declares a 500 Internal Server Error response
+func E500[T any]() ErrorResponse { return E[T](http.StatusInternalServerError) }
+
+// E503 declares a 503 Service Unavailable response
+func E503[T any]() ErrorResponse { return E[T](http.StatusServiceUnavailable) }
+
+// WithDevelopmentMode enables checks that are too costly or noisy for production,
+// such as logging the error statuses endpoints send without declaring them
+func WithDevelopmentMode() func(*App) {
+	return func(a *App) {
+		a.config.development = true
+	}
+}
+
+// checkDeclaredStatus logs the error responses of an endpoint that were not
+// declared with Errors. Binding, validation and internal errors are always documented.
+func checkDeclaredStatus(c *fiber.Ctx, declared []ErrorResponse) {
+	status := c.Response().StatusCode()
+
+	switch {
+	case status < http.StatusBadRequest,
+		status == http.StatusBadRequest,
+		status == http.StatusUnprocessableEntity,
+		status == http.StatusInternalServerError:
+		return
+	}
+
+	for _, response := range declared {
+		if response.Status == status {
+			return
+		}
+	}
+
+	log.Printf("undeclared error status %d in handler %s %s, declare it with Errors", status, c.Method(), c.Route().Path)
+}
@@ -0,0 +1,351 @@
+package fast
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ConflictPolicy defines what happens when a route conflicts with a registered one
+type ConflictPolicy int
+
+const (
+	// WarnOnConflict logs a warning and registers the route anyway
+	WarnOnConflict ConflictPolicy = iota
+	// ErrorOnConflict makes Register return the conflict as an error
+	ErrorOnConflict
+	// PanicOnConflict panics on the first conflict
+	PanicOnConflict
+)
+
+// ConflictKind describes how two routes conflict
+type ConflictKind string
+
+const (
+	// ConflictDuplicate means both routes have the same method and path
+	ConflictDuplicate ConflictKind = "duplicate"
+	// ConflictShadowed means the new route can never be reached because
+	// a route registered before it matches every request it would match,
+	// e.g. /users/me registered after /users/:id
+	ConflictShadowed ConflictKind = "shadowed"
+	// ConflictMethodSplit means the same path is served with different
+	// methods from different groups
+	ConflictMethodSplit ConflictKind = "method split"
+)
+
+var (
+	// ErrRouteConflict is the error matched by every RouteConflictError
+	ErrRouteConflict = errors.New("route conflict")
+	// ErrDuplicateRoute is matched by RouteConflictErrors of the ConflictDuplicate kind
+	ErrDuplicateRoute = errors.New("duplicate route")
+)
+
+// RouteConflictError is returned when a route conflicts with a registered one
+type RouteConflictError struct {
+	Kind           ConflictKind
+	Method         string
+	Path           string
+	ExistingMethod string
+	ExistingPath   string
+}
+
+func (e *RouteConflictError) Error() string {
+	switch e.Kind {
+	case ConflictShadowed:
+		return fmt.Sprintf("route conflict: %s %s is shadowed by %s %s", e.Method, e.Path, e.ExistingMethod, e.ExistingPath)
+	case ConflictMethodSplit:
+		return fmt.Sprintf("route conflict: %s %s is registered by another group as %s %s", e.Method, e.Path, e.ExistingMethod, e.ExistingPath)
+	default:
+		return fmt.Sprintf("route conflict: %s %s is already registered as %s %s", e.Method, e.Path, e.ExistingMethod, e.ExistingPath)
+	}
+}
+
+// Is makes errors.Is(err, ErrRouteConflict) match any conflict
+// and errors.Is(err, ErrDuplicateRoute) match duplicates
+func (e *RouteConflictError) Is(target error) bool {
+	return target == ErrRouteConflict || (target == ErrDuplicateRoute && e.Kind == ConflictDuplicate)
+}
+
+// Route describes a route registered on an App
+type Route struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	// Handler is the handler type and method serving the route, e.g. main.UserHandler.HandleList
+	Handler string `json:"handler"`
+	group   int
+}
+
+// routeTable keeps every route registered on an App to detect conflicts
+type routeTable struct {
+	policy ConflictPolicy
+	routes []Route
+	groups int
+}
+
+// newGroup returns a new identifier to tell routes from different groups apart
+func (t *routeTable) newGroup() int {
+	t.groups++
+	return t.groups
+}
+
+// check returns the conflicts of the given routes with the registered
+// ones and with each other as RegistrationErrors, applying the conflict policy
+func (t *routeTable) check(routes []Route) error {
+	var errs []error
+
+	for idx, r := range routes {
+		for _, existing := range append(t.routes, routes[:idx]...) {
+			conflict := findConflict(existing, r)
+			if conflict == nil {
+				continue
+			}
+
+			switch t.policy {
+			case PanicOnConflict:
+				panic(conflict.Error())
+			case ErrorOnConflict:
+				errs = append(errs, &RegistrationError{
+					Handler: r.Handler,
+					Route:   r.Method + " " + r.Path,
+					Reason:  conflict,
+				})
+			default:
+				log.Printf("warning: %s", conflict)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// add stores the given routes in the table
+func (t *routeTable) add(routes []Route) {
+	t.routes = append(t.routes, routes...)
+}
+
+// findConflict returns the conflict between a registered route and a new one, if any
+func findConflict(existing, r Route) *RouteConflictError {
+	var (
+		existingSegments = routeSegments(existing.Path)
+		segments         = routeSegments(r.Path)
+		sameMethod       = strings.EqualFold(existing.Method, r.Method)
+		kind             ConflictKind
+	)
+
+	switch {
+	case sameMethod && sameShape(existingSegments, segments):
+		kind = ConflictDuplicate
+	case sameMethod && shadows(existingSegments, segments):
+		kind = ConflictShadowed
+	case !sameMethod && existing.group != r.group && sameShape(existingSegments, segments):
+		kind = ConflictMethodSplit
+	default:
+		return nil
+	}
+
+	return &RouteConflictError{
+		Kind:           kind,
+		Method:         r.Method,
+		Path:           r.Path,
+		ExistingMethod: existing.Method,
+		ExistingPath:   existing.Path,
+	}
+}
+
+// segmentKind is the kind of a route segment
+type segmentKind int
+
+const (
+	staticSegment segmentKind = iota
+	// paramSegment is a single parameter, e.g. :id or :id<int>?
+	paramSegment
+	// wildcardSegment is *, matching the rest of the path, even if empty
+	wildcardSegment
+	// plusSegment is +, matching the rest of the path when it is not empty
+	plusSegment
+	// mixedSegment mixes parameters and text, e.g. :name.:ext
+	mixedSegment
+)
+
+// routeSegment is a segment of a route path
+type routeSegment struct {
+	kind segmentKind
+	// value is the text of static and mixed segments
+	// and the constraints of parameters, e.g. int;min(1)
+	value    string
+	optional bool
+}
+
+// routeSegments splits a route path into its segments
+func routeSegments(p string) []routeSegment {
+	p = strings.Trim(p, "/")
+	if p == "" {
+		return nil
+	}
+
+	parts := strings.Split(p, "/")
+	segments := make([]routeSegment, len(parts))
+	for idx, part := range parts {
+		segments[idx] = parseSegment(part)
+	}
+
+	return segments
+}
+
+// parseSegment returns the kind of a segment, parameter names are dropped
+// since they do not change the requests a route matches
+func parseSegment(segment string) routeSegment {
+	if !isDynamicSegment(segment) {
+		return routeSegment{kind: staticSegment, value: segment}
+	}
+
+	if isWildcardSegment(segment) {
+		if segment[0] == '+' {
+			return routeSegment{kind: plusSegment}
+		}
+		return routeSegment{kind: wildcardSegment}
+	}
+
+	_, params := openAPIPath("/" + segment)
+	if len(params) != 1 || segment[0] != ':' {
+		return routeSegment{kind: mixedSegment, value: segment}
+	}
+
+	// A single parameter, optionally followed by its constraints and ?
+	end := 1
+	for end < len(segment) && isParamChar(segment[end]) {
+		end++
+	}
+
+	rest, optional := strings.CutSuffix(segment[end:], "?")
+	if rest != "" && !(strings.HasPrefix(rest, "<") && strings.HasSuffix(rest, ">")) {
+		return routeSegment{kind: mixedSegment, value: segment}
+	}
+
+	return routeSegment{
+		kind:     paramSegment,
+		value:    strings.TrimSuffix(strings.TrimPrefix(rest, "<"), ">"),
+		optional: optional,
+	}
+}
+
+// isDynamicSegment reports whether a segment matches more than one value
+func isDynamicSegment(segment string) bool {
+	return strings.ContainsAny(segment, ":*+")
+}
+
+// isWildcardSegment reports whether a segment matches the rest of the path,
+// e.g. * or +2
+func isWildcardSegment(segment string) bool {
+	return segment != "" && (segment[0] == '*' || segment[0] == '+') &&
+		strings.Trim(segment[1:], "0123456789") == ""
+}
+
+// sameShape reports whether both routes match exactly the same requests,
+// ignoring parameter names
+func sameShape(a, b []routeSegment) bool {
+	return slices.Equal(a, b)
+}
+
+// shadows reports whether the general route matches every request the specific
+// one does. Routes with optional parameters are checked in every variant.
+func shadows(general, specific []routeSegment) bool {
+	generalVariants := routeVariants(general)
+
+	for _, variant := range routeVariants(specific) {
+		shadowed := slices.ContainsFunc(generalVariants, func(g []routeSegment) bool {
+			return shadowsVariant(g, variant)
+		})
+
+		if !shadowed {
+			return false
+		}
+	}
+
+	return true
+}
+
+// routeVariants expands the optional parameters of a route into
+// the routes with and without each of them
+func routeVariants(segments []routeSegment) [][]routeSegment {
+	variants := [][]routeSegment{nil}
+
+	for _, segment := range segments {
+		var next [][]routeSegment
+
+		for _, variant := range variants {
+			if segment.optional {
+				next = append(next, slices.Clone(variant))
+			}
+
+			required := segment
+			required.optional = false
+			next = append(next, append(slices.Clone(variant), required))
+		}
+
+		variants = next
+	}
+
+	return variants
+}
+
+// shadowsVariant reports whether the general route matches every request
+// the specific one does, both without optional parameters
+func shadowsVariant(general, specific []routeSegment) bool {
+	if n := len(general); n > 0 && (general[n-1].kind == wildcardSegment || general[n-1].kind == plusSegment) {
+		if len(specific) < n-1 {
+			return false
+		}
+
+		// + needs at least one more segment, which a specific * does not guarantee
+		if general[n-1].kind == plusSegment &&
+			(len(specific) < n || specific[n-1].kind == wildcardSegment) {
+			return false
+		}
+
+		general, specific = general[:n-1], specific[:n-1]
+	}
+
+	if len(general) != len(specific) {
+		return false
+	}
+
+	for idx := range general {
+		if !shadowsSegment(general[idx], specific[idx]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// shadowsSegment reports whether the general segment matches every value the
+// specific one does. Segments mixing parameters and text only shadow themselves.
+func shadowsSegment(general, specific routeSegment) bool {
+	switch general.kind {
+	case staticSegment, mixedSegment:
+		return general == specific
+	case paramSegment:
+	default:
+		return false
+	}
+
+	switch specific.kind {
+	case staticSegment:
+		if general.value == "" {
+			return true
+		}
+
+		return fiber.RoutePatternMatch("/"+specific.value, "/:param<"+general.value+">")
+	case paramSegment:
+		return general.value == "" || general.value == specific.value
+	case mixedSegment:
+		return general.value == ""
+	default:
+		return false
+	}
+}
@@ -0,0 +1,152 @@
+package fast
+
+import (
+	"testing"
+)
+
+func TestFindConflict(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing Route
+		route    Route
+		want     ConflictKind
+	}{
+		{
+			name:     "same path",
+			existing: Route{Method: "GET", Path: "/users/:id"},
+			route:    Route{Method: "GET", Path: "/users/:id"},
+			want:     ConflictDuplicate,
+		},
+		{
+			name:     "parameter names are ignored",
+			existing: Route{Method: "GET", Path: "/users/:id"},
+			route:    Route{Method: "GET", Path: "/users/:name"},
+			want:     ConflictDuplicate,
+		},
+		{
+			name:     "same constraints",
+			existing: Route{Method: "GET", Path: "/users/:id<int>"},
+			route:    Route{Method: "GET", Path: "/users/:uid<int>"},
+			want:     ConflictDuplicate,
+		},
+		{
+			name:     "different constraints",
+			existing: Route{Method: "GET", Path: "/:id<int>"},
+			route:    Route{Method: "GET", Path: "/:slug<alpha>"},
+		},
+		{
+			name:     "different methods",
+			existing: Route{Method: "GET", Path: "/users/:id"},
+			route:    Route{Method: "POST", Path: "/users/:id"},
+		},
+		{
+			name:     "static path after parameter",
+			existing: Route{Method: "GET", Path: "/users/:id"},
+			route:    Route{Method: "GET", Path: "/users/me"},
+			want:     ConflictShadowed,
+		},
+		{
+			name:     "parameter after static path",
+			existing: Route{Method: "GET", Path: "/users/me"},
+			route:    Route{Method: "GET", Path: "/users/:id"},
+		},
+		{
+			name:     "static path not matching the constraint",
+			existing: Route{Method: "GET", Path: "/users/:id<int>"},
+			route:    Route{Method: "GET", Path: "/users/me"},
+		},
+		{
+			name:     "static path matching the constraint",
+			existing: Route{Method: "GET", Path: "/users/:id<int>"},
+			route:    Route{Method: "GET", Path: "/users/42"},
+			want:     ConflictShadowed,
+		},
+		{
+			name:     "constrained parameter after parameter",
+			existing: Route{Method: "GET", Path: "/users/:id"},
+			route:    Route{Method: "GET", Path: "/users/:id<int>"},
+			want:     ConflictShadowed,
+		},
+		{
+			name:     "parameter after constrained parameter",
+			existing: Route{Method: "GET", Path: "/users/:id<int>"},
+			route:    Route{Method: "GET", Path: "/users/:name"},
+		},
+		{
+			name:     "optional parameter shadows its parent",
+			existing: Route{Method: "GET", Path: "/users/:id?"},
+			route:    Route{Method: "GET", Path: "/users"},
+			want:     ConflictShadowed,
+		},
+		{
+			name:     "optional parameter shadows a child",
+			existing: Route{Method: "GET", Path: "/users/:id?"},
+			route:    Route{Method: "GET", Path: "/users/me"},
+			want:     ConflictShadowed,
+		},
+		{
+			name:     "parent before optional parameter",
+			existing: Route{Method: "GET", Path: "/users"},
+			route:    Route{Method: "GET", Path: "/users/:id?"},
+		},
+		{
+			name:     "optional and required parameters",
+			existing: Route{Method: "GET", Path: "/users/:id"},
+			route:    Route{Method: "GET", Path: "/users/:id?"},
+		},
+		{
+			name:     "wildcard",
+			existing: Route{Method: "GET", Path: "/files/*"},
+			route:    Route{Method: "GET", Path: "/files/a/b"},
+			want:     ConflictShadowed,
+		},
+		{
+			name:     "wildcard matches an empty rest",
+			existing: Route{Method: "GET", Path: "/files/*"},
+			route:    Route{Method: "GET", Path: "/files"},
+			want:     ConflictShadowed,
+		},
+		{
+			name:     "plus needs a rest",
+			existing: Route{Method: "GET", Path: "/files/+"},
+			route:    Route{Method: "GET", Path: "/files"},
+		},
+		{
+			name:     "plus does not shadow a wildcard",
+			existing: Route{Method: "GET", Path: "/files/+"},
+			route:    Route{Method: "GET", Path: "/files/*"},
+		},
+		{
+			name:     "mixed segment after parameter",
+			existing: Route{Method: "GET", Path: "/files/:name"},
+			route:    Route{Method: "GET", Path: "/files/:name.:ext"},
+			want:     ConflictShadowed,
+		},
+		{
+			name:     "same path from another group",
+			existing: Route{Method: "GET", Path: "/users/:id", group: 1},
+			route:    Route{Method: "DELETE", Path: "/users/:uid", group: 2},
+			want:     ConflictMethodSplit,
+		},
+		{
+			name:     "same path from the same group",
+			existing: Route{Method: "GET", Path: "/users/:id", group: 1},
+			route:    Route{Method: "DELETE", Path: "/users/:id", group: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conflict := findConflict(tt.existing, tt.route)
+
+			var got ConflictKind
+			if conflict != nil {
+				got = conflict.Kind
+			}
+
+			if got != tt.want {
+				t.Errorf("conflict = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
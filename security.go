@@ -0,0 +1,205 @@
+package fast
+
+import (
+	"log"
+	"maps"
+	"slices"
+	"sync"
+	"unsafe"
+)
+
+// SecuritySchemeObject describes an authentication method of the API
+type SecuritySchemeObject struct {
+	Type             string            `json:"type"` // apiKey, http, oauth2, openIdConnect
+	Description      string            `json:"description,omitempty"`
+	Name             string            `json:"name,omitempty"` // apiKey
+	In               string            `json:"in,omitempty"`   // apiKey: query, header or cookie
+	Scheme           string            `json:"scheme,omitempty"`
+	BearerFormat     string            `json:"bearerFormat,omitempty"`
+	Flows            *OAuthFlowsObject `json:"flows,omitempty"`
+	OpenIDConnectURL string            `json:"openIdConnectUrl,omitempty"`
+}
+
+// OAuthFlowsObject lists the OAuth 2 flows supported by a security scheme
+type OAuthFlowsObject struct {
+	Implicit          *OAuthFlowObject `json:"implicit,omitempty"`
+	Password          *OAuthFlowObject `json:"password,omitempty"`
+	ClientCredentials *OAuthFlowObject `json:"clientCredentials,omitempty"`
+	AuthorizationCode *OAuthFlowObject `json:"authorizationCode,omitempty"`
+}
+
+// OAuthFlowObject describes a single OAuth 2 flow
+type OAuthFlowObject struct {
+	AuthorizationURL string            `json:"authorizationUrl,omitempty"`
+	TokenURL         string            `json:"tokenUrl,omitempty"`
+	RefreshURL       string            `json:"refreshUrl,omitempty"`
+	Scopes           map[string]string `json:"scopes"`
+}
+
+// SecurityRequirement maps the names of the security schemes an operation
+// requires to the scopes it needs, all of them must be satisfied
+type SecurityRequirement map[string][]string
+
+// APIKeySecurity returns an API key scheme sent in a header, query parameter or cookie
+func APIKeySecurity(in, name string) SecuritySchemeObject {
+	return SecuritySchemeObject{
+		Type: "apiKey",
+		In:   in,
+		Name: name,
+	}
+}
+
+// BearerSecurity returns an HTTP bearer scheme, format is a hint such as JWT
+func BearerSecurity(format string) SecuritySchemeObject {
+	return SecuritySchemeObject{
+		Type:         "http",
+		Scheme:       "bearer",
+		BearerFormat: format,
+	}
+}
+
+// BasicSecurity returns an HTTP basic scheme
+func BasicSecurity() SecuritySchemeObject {
+	return SecuritySchemeObject{
+		Type:   "http",
+		Scheme: "basic",
+	}
+}
+
+// OAuth2Security returns an OAuth 2 scheme with the given flows
+func OAuth2Security(flows OAuthFlowsObject) SecuritySchemeObject {
+	return SecuritySchemeObject{
+		Type:  "oauth2",
+		Flows: &flows,
+	}
+}
+
+// OpenIDConnectSecurity returns an OpenID Connect scheme with the given discovery URL
+func OpenIDConnectSecurity(url string) SecuritySchemeObject {
+	return SecuritySchemeObject{
+		Type:             "openIdConnect",
+		OpenIDConnectURL: url,
+	}
+}
+
+// WithSecurityScheme adds a security scheme to the OpenAPI schema, endpoints
+// and groups refer to it by name with their Security method
+//
+//	fast.WithSecurityScheme("apiKey", fast.APIKeySecurity("header", "X-API-Key"))
+func WithSecurityScheme(name string, scheme SecuritySchemeObject) func(*App) {
+	return func(a *App) {
+		if a.securitySchemes == nil {
+			a.securitySchemes = make(map[string]SecuritySchemeObject)
+		}
+
+		a.securitySchemes[name] = scheme
+	}
+}
+
+// RegisterSecurityScheme adds a security scheme to the generated schema
+func (g *OpenAPIGenerator) RegisterSecurityScheme(name string, scheme SecuritySchemeObject) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.cached = nil
+	g.securitySchemes[name] = scheme
+}
+
+// Security documents that every handler registered on the group requires the
+// given security scheme, usually enforced by a group middleware
+//
+//	app.Group("/admin", requireAPIKey).Security("apiKey")
+func (g Group) Security(scheme string, scopes ...string) Group {
+	cfg := *g.config
+	cfg.security = cfg.security.with(scheme, scopes)
+	g.config = &cfg
+	return g
+}
+
+// middlewareSecurity maps the middlewares that enforce a security scheme,
+// built with Secure or TypedMiddleware, to their requirement. Middlewares are
+// plain functions, so they are keyed by the address of their closure, which
+// the map keeps alive so it is never reused by another middleware.
+var middlewareSecurity sync.Map // map[unsafe.Pointer]SecurityRequirement
+
+// Secure returns a middleware that runs the given one and documents that
+// every handler it protects requires the security scheme, wherever it is
+// used: on a group, on Register or on an endpoint.
+//
+//	requireAPIKey := fast.Secure(checkAPIKey, "apiKey")
+//	app.Group("/admin", requireAPIKey).MustRegister("/users", UserHandler{})
+func Secure(middleware Middleware, scheme string, scopes ...string) Middleware {
+	secured := func(c *Context) error {
+		return middleware(c)
+	}
+
+	setMiddlewareSecurity(secured, middlewaresSecurity([]Middleware{middleware}).with(scheme, scopes))
+
+	return secured
+}
+
+// setMiddlewareSecurity records the requirement of a middleware
+func setMiddlewareSecurity(middleware Middleware, requirement SecurityRequirement) {
+	middlewareSecurity.Store(middlewareAddress(middleware), requirement)
+}
+
+// middlewaresSecurity returns the requirements of the given middlewares
+func middlewaresSecurity(middlewares []Middleware) SecurityRequirement {
+	var requirement SecurityRequirement
+
+	for _, middleware := range middlewares {
+		if value, ok := middlewareSecurity.Load(middlewareAddress(middleware)); ok {
+			requirement = requirement.merge(value.(SecurityRequirement))
+		}
+	}
+
+	return requirement
+}
+
+// middlewareAddress returns the address of the closure of a middleware,
+// each call to a function returning a closure gets a different one
+func middlewareAddress(middleware Middleware) unsafe.Pointer {
+	return *(*unsafe.Pointer)(unsafe.Pointer(&middleware))
+}
+
+// with returns a copy of the requirement that also requires the given scheme
+func (r SecurityRequirement) with(scheme string, scopes []string) SecurityRequirement {
+	requirement := maps.Clone(r)
+	if requirement == nil {
+		requirement = make(SecurityRequirement)
+	}
+
+	requirement[scheme] = append(slices.Clone(requirement[scheme]), scopes...)
+	if requirement[scheme] == nil {
+		// Schemes without scopes are documented with an empty list
+		requirement[scheme] = []string{}
+	}
+
+	return requirement
+}
+
+// merge returns the requirement of both, e.g. of a group and one of its endpoints
+func (r SecurityRequirement) merge(other SecurityRequirement) SecurityRequirement {
+	requirement := r
+	for scheme, scopes := range other {
+		requirement = requirement.with(scheme, scopes)
+	}
+
+	return requirement
+}
+
+// operationSecurity returns the security of an operation, warning about schemes
+// that were not registered with WithSecurityScheme
+func (g *OpenAPIGenerator) operationSecurity(requirement SecurityRequirement) []SecurityRequirement {
+	if len(requirement) == 0 {
+		return nil
+	}
+
+	for _, scheme := range slices.Sorted(maps.Keys(requirement)) {
+		if _, exists := g.securitySchemes[scheme]; !exists {
+			log.Printf("OpenAPI security scheme %s is not registered, add it with WithSecurityScheme", scheme)
+		}
+	}
+
+	return []SecurityRequirement{requirement}
+}
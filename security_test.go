@@ -0,0 +1,135 @@
+package fast
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+type securedHandler struct {
+	middlewares []Middleware
+}
+
+func (h securedHandler) HandleGet() Handler {
+	return Endpoint[struct{}, Out]().
+		Method(http.MethodGet).
+		Middlewares(h.middlewares...).
+		Handle(func(c *Context, in struct{}) (Out, error) {
+			return "ok", nil
+		})
+}
+
+type apiKeyIn struct {
+	Key string `header:"X-API-Key" validate:"required"`
+}
+
+func TestMiddlewareSecurity(t *testing.T) {
+	pass := func(c *Context) error { return nil }
+
+	requireAPIKey := Secure(pass, "apiKey")
+	requireAdmin := Secure(requireAPIKey, "oauth", "admin")
+	requireKey := TypedMiddleware[apiKeyIn, string]().
+		Security("apiKey").
+		Handle(func(c *Context, in apiKeyIn) (string, error) {
+			return in.Key, nil
+		})
+
+	tests := []struct {
+		name     string
+		register func(app App)
+		want     []SecurityRequirement
+	}{
+		{
+			name: "group",
+			register: func(app App) {
+				app.Group("/group", requireAPIKey).MustRegister("/items", securedHandler{})
+			},
+			want: []SecurityRequirement{{"apiKey": {}}},
+		},
+		{
+			name: "register",
+			register: func(app App) {
+				app.MustRegister("/items", securedHandler{}, requireAPIKey)
+			},
+			want: []SecurityRequirement{{"apiKey": {}}},
+		},
+		{
+			name: "endpoint",
+			register: func(app App) {
+				app.MustRegister("/items", securedHandler{middlewares: []Middleware{requireAPIKey}})
+			},
+			want: []SecurityRequirement{{"apiKey": {}}},
+		},
+		{
+			name: "typed middleware",
+			register: func(app App) {
+				app.MustRegister("/items", securedHandler{}, requireKey)
+			},
+			want: []SecurityRequirement{{"apiKey": {}}},
+		},
+		{
+			name: "nested",
+			register: func(app App) {
+				app.Group("/group").Security("bearer").MustRegister("/items", securedHandler{}, requireAdmin)
+			},
+			want: []SecurityRequirement{{"apiKey": {}, "bearer": {}, "oauth": {"admin"}}},
+		},
+		{
+			name: "plain middleware",
+			register: func(app App) {
+				app.MustRegister("/items", securedHandler{}, pass)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestApp(t,
+				WithExperimentalOpenAPISchema(),
+				WithSecurityScheme("apiKey", APIKeySecurity("header", "X-API-Key")),
+				WithSecurityScheme("bearer", BearerSecurity("JWT")),
+				WithSecurityScheme("oauth", OAuth2Security(OAuthFlowsObject{})),
+			)
+			tt.register(app)
+
+			schema, err := app.OpenAPI()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(schema.Paths) == 0 {
+				t.Fatal("no path was documented")
+			}
+
+			for template, item := range schema.Paths {
+				if got := item["get"].Security; !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("%s security = %v, want %v", template, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestSecureRunsTheMiddleware(t *testing.T) {
+	requireAPIKey := Secure(func(c *Context) error {
+		if c.Get("X-API-Key") == "" {
+			return UnauthorizedError("missing api key")
+		}
+		return nil
+	}, "apiKey")
+
+	app := newTestApp(t)
+	app.MustRegister("/items", securedHandler{}, requireAPIKey)
+
+	if status, body := send(t, app, httptest.NewRequest(http.MethodGet, "/items", nil)); status != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d: %s", status, http.StatusUnauthorized, body)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/items", nil)
+	req.Header.Set("X-API-Key", "secret")
+
+	if status, body := send(t, app, req); status != http.StatusOK {
+		t.Errorf("status = %d, want %d: %s", status, http.StatusOK, body)
+	}
+}
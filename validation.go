@@ -0,0 +1,148 @@
+package fast
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/esequiel378/fast/internal/validator"
+	v10 "github.com/go-playground/validator/v10"
+)
+
+type (
+	// Validator validates the inputs and outputs of endpoints and
+	// translates its errors into field errors, see WithValidator
+	Validator = validator.Validator
+	// FieldError is the validation error of a single field
+	FieldError = validator.Error
+)
+
+// ContextValidator is implemented by inputs with rules that need the request
+// or I/O, e.g. checking that an email is not taken. Validate runs once the
+// input is bound, even when it failed the `validate` tags, so every invalid
+// field is reported at once. It must not assume that the tags passed.
+// Use c.UserContext() for cancellation and c.Locals for request dependencies.
+//
+// Returning FieldErrors answers with 422 together with the errors of the
+// `validate` tags, in the same format. Any other error is handled like the
+// errors returned by the endpoint, unless the tags already failed.
+type ContextValidator interface {
+	Validate(c *Context) error
+}
+
+// FieldErrors reports invalid input fields from a ContextValidator
+//
+//	if taken {
+//		return fast.FieldErrors{{Field: "email", Message: "email is already taken"}}
+//	}
+type FieldErrors []FieldError
+
+func (e FieldErrors) Error() string {
+	msgs := make([]string, len(e))
+	for idx, fieldErr := range e {
+		msgs[idx] = fieldErr.Message
+		if fieldErr.Field != "" {
+			msgs[idx] = fieldErr.Field + ": " + fieldErr.Message
+		}
+	}
+
+	return "invalid fields: " + strings.Join(msgs, ", ")
+}
+
+// ErrValidationNotSupported is returned by New when a custom validation is
+// registered on a validator that does not support it
+var ErrValidationNotSupported = errors.New("validator does not support custom validations")
+
+// validationRegistrar is implemented by validators that support custom rules
+type validationRegistrar interface {
+	RegisterValidation(tag string, fn v10.Func, message string) error
+	RegisterTranslation(locale, tag, message string) error
+	RegisterStructValidation(fn v10.StructLevelFunc, types ...any)
+}
+
+// WithValidator replaces the validator of the app.
+// Every struct input and output is passed to it, even without `validate` tags.
+// The other validation options apply to the current validator, so it must go first.
+func WithValidator(v Validator) func(*App) {
+	return func(a *App) {
+		a.config.validator = v
+		a.config.validateAll = true
+	}
+}
+
+// WithValidation registers a custom validation tag and its English message.
+// The message can use {0} for the field name and {1} for the tag parameter.
+//
+//	fast.WithValidation("slug", func(fl validator.FieldLevel) bool {
+//		return slugPattern.MatchString(fl.Field().String())
+//	}, "{0} must be a valid slug")
+func WithValidation(tag string, fn v10.Func, message string) func(*App) {
+	return func(a *App) {
+		registrar, ok := a.config.validator.(validationRegistrar)
+		if !ok {
+			a.err = errors.Join(a.err, ErrValidationNotSupported)
+			return
+		}
+
+		if err := registrar.RegisterValidation(tag, fn, message); err != nil {
+			a.err = errors.Join(a.err, err)
+			return
+		}
+
+		if a.config.customValidations == nil {
+			a.config.customValidations = make(map[string]bool)
+		}
+		a.config.customValidations[tag] = true
+	}
+}
+
+// WithValidationMessage sets the message of a validation tag in a locale
+// registered with WithLocale, e.g.
+//
+//	fast.WithValidationMessage("es", "slug", "{0} debe ser un slug válido")
+func WithValidationMessage(locale, tag, message string) func(*App) {
+	return func(a *App) {
+		registrar, ok := a.config.validator.(validationRegistrar)
+		if !ok {
+			a.err = errors.Join(a.err, ErrValidationNotSupported)
+			return
+		}
+
+		if err := registrar.RegisterTranslation(locale, tag, message); err != nil {
+			a.err = errors.Join(a.err, err)
+		}
+	}
+}
+
+// WithStructValidation registers a struct level validation for the given types,
+// for rules that involve more than one field. Report errors with StructLevel.ReportError.
+//
+//	fast.WithStructValidation(func(sl validator.StructLevel) {
+//		in := sl.Current().Interface().(TransferIn)
+//		if in.From == in.To {
+//			sl.ReportError(in.To, "to", "To", "nefield", "from")
+//		}
+//	}, TransferIn{})
+func WithStructValidation(fn v10.StructLevelFunc, types ...any) func(*App) {
+	return func(a *App) {
+		registrar, ok := a.config.validator.(validationRegistrar)
+		if !ok {
+			a.err = errors.Join(a.err, ErrValidationNotSupported)
+			return
+		}
+
+		registrar.RegisterStructValidation(fn, types...)
+
+		if a.config.structValidations == nil {
+			a.config.structValidations = make(map[reflect.Type]bool)
+		}
+
+		for _, value := range types {
+			t := reflect.TypeOf(value)
+			for t != nil && t.Kind() == reflect.Ptr {
+				t = t.Elem()
+			}
+			a.config.structValidations[t] = true
+		}
+	}
+}
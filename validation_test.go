@@ -0,0 +1,162 @@
+package fast
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	v10 "github.com/go-playground/validator/v10"
+)
+
+type transferIn struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type batchIn struct {
+	Transfers []transferIn `json:"transfers"`
+}
+
+type plainIn struct {
+	Name string `json:"name"`
+}
+
+type transferHandler struct{}
+
+func (transferHandler) HandleCreate() Handler {
+	return Endpoint[transferIn, Out]().
+		Method(http.MethodPost).
+		Handle(func(c *Context, in transferIn) (Out, error) {
+			return "ok", nil
+		})
+}
+
+func TestStructValidationScope(t *testing.T) {
+	app := newTestApp(t, WithStructValidation(func(sl v10.StructLevel) {
+		in := sl.Current().Interface().(transferIn)
+		if in.From == in.To {
+			sl.ReportError(in.To, "to", "To", "nefield", "from")
+		}
+	}, transferIn{}))
+	app.MustRegister("/transfers", transferHandler{})
+
+	tests := []struct {
+		name string
+		t    reflect.Type
+		want bool
+	}{
+		{name: "registered type", t: reflect.TypeFor[transferIn](), want: true},
+		{name: "type reaching a registered type", t: reflect.TypeFor[batchIn](), want: true},
+		{name: "unrelated type", t: reflect.TypeFor[plainIn](), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := app.config.validates(false, structTypes(tt.t, make(map[reflect.Type]bool)))
+			if got != tt.want {
+				t.Errorf("validates = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/transfers", strings.NewReader(`{"from":"a","to":"a"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	if status, body := send(t, app, req); status != http.StatusUnprocessableEntity {
+		t.Errorf("status = %d, want %d: %s", status, http.StatusUnprocessableEntity, body)
+	}
+}
+
+var errEmailLookup = errors.New("email lookup failed")
+
+type signUpIn struct {
+	Name  string `json:"name" validate:"required"`
+	Email string `json:"email"`
+}
+
+func (in signUpIn) Validate(c *Context) error {
+	switch in.Email {
+	case "taken@example.com":
+		return FieldErrors{{Field: "email", Message: "email is already taken"}}
+	case "down@example.com":
+		return errEmailLookup
+	}
+
+	return nil
+}
+
+type signUpHandler struct{}
+
+func (signUpHandler) HandleCreate() Handler {
+	return Endpoint[signUpIn, Out]().
+		Method(http.MethodPost).
+		Handle(func(c *Context, in signUpIn) (Out, error) {
+			return "ok", nil
+		})
+}
+
+func TestContextValidatorMergesFieldErrors(t *testing.T) {
+	app := newTestApp(t, WithErrorMappers(MapError(errEmailLookup, http.StatusServiceUnavailable)))
+	app.MustRegister("/users", signUpHandler{})
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "valid",
+			body:       `{"name":"rex","email":"rex@example.com"}`,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "tags only",
+			body:       `{"email":"rex@example.com"}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   `{"errors":[{"field":"name","message":"name is a required field"}]}`,
+		},
+		{
+			name:       "hook only",
+			body:       `{"name":"rex","email":"taken@example.com"}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   `{"errors":[{"field":"email","message":"email is already taken"}]}`,
+		},
+		{
+			name:       "tags and hook",
+			body:       `{"email":"taken@example.com"}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   `{"errors":[{"field":"name","message":"name is a required field"},{"field":"email","message":"email is already taken"}]}`,
+		},
+		{
+			name:       "hook error",
+			body:       `{"name":"rex","email":"down@example.com"}`,
+			wantStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:       "hook error after failed tags",
+			body:       `{"email":"down@example.com"}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   `{"errors":[{"field":"name","message":"name is a required field"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			status, body := send(t, app, req)
+			if status != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", status, tt.wantStatus, body)
+			}
+
+			if tt.wantBody != "" && body != tt.wantBody {
+				t.Errorf("body = %s, want %s", body, tt.wantBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package fast
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// yamlEntry is a key of a JSON object, kept in document order
+type yamlEntry struct {
+	key   string
+	value any
+}
+
+// plainYAMLScalar matches the strings that can be written without quotes
+var plainYAMLScalar = regexp.MustCompile(`^[A-Za-z_$/][A-Za-z0-9_$./{}\- ]*$`)
+
+// jsonToYAML converts a JSON document to YAML, keeping the order of the keys.
+// Strings are quoted unless they can not be read as anything else.
+func jsonToYAML(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	value, err := readJSONValue(dec)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	writeYAMLValue(&buf, value, 0)
+
+	return buf.Bytes(), nil
+}
+
+// readJSONValue reads the next value, objects become ordered []yamlEntry
+func readJSONValue(dec *json.Decoder) (any, error) {
+	token, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch token {
+	case json.Delim('{'):
+		entries := []yamlEntry{}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+
+			value, err := readJSONValue(dec)
+			if err != nil {
+				return nil, err
+			}
+
+			entries = append(entries, yamlEntry{key: key.(string), value: value})
+		}
+		_, err := dec.Token()
+		return entries, err
+
+	case json.Delim('['):
+		items := []any{}
+		for dec.More() {
+			item, err := readJSONValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		_, err := dec.Token()
+		return items, err
+
+	case json.Delim('}'), json.Delim(']'):
+		return nil, errors.New("unexpected end of JSON value")
+	}
+
+	return token, nil
+}
+
+// writeYAMLValue writes a value that starts on its own line at the given indentation
+func writeYAMLValue(buf *bytes.Buffer, value any, indent int) {
+	switch value := value.(type) {
+	case []yamlEntry:
+		for idx, entry := range value {
+			// The first key of a sequence item follows the dash
+			if idx > 0 || buf.Len() == 0 || buf.Bytes()[buf.Len()-1] == '\n' {
+				buf.WriteString(strings.Repeat(" ", indent))
+			}
+
+			buf.WriteString(yamlScalar(entry.key))
+			buf.WriteByte(':')
+			writeYAMLChild(buf, entry.value, indent)
+		}
+
+	case []any:
+		for _, item := range value {
+			buf.WriteString(strings.Repeat(" ", indent))
+			buf.WriteByte('-')
+
+			if entries, ok := item.([]yamlEntry); ok && len(entries) > 0 {
+				buf.WriteByte(' ')
+				writeYAMLValue(buf, entries, indent+2)
+				continue
+			}
+
+			writeYAMLChild(buf, item, indent)
+		}
+
+	default:
+		buf.WriteString(yamlScalar(value))
+		buf.WriteByte('\n')
+	}
+}
+
+// writeYAMLChild writes the value of a key or sequence item after its indicator
+func writeYAMLChild(buf *bytes.Buffer, value any, indent int) {
+	switch value := value.(type) {
+	case []yamlEntry:
+		if len(value) == 0 {
+			buf.WriteString(" {}\n")
+			return
+		}
+	case []any:
+		if len(value) == 0 {
+			buf.WriteString(" []\n")
+			return
+		}
+	default:
+		buf.WriteByte(' ')
+		writeYAMLValue(buf, value, indent)
+		return
+	}
+
+	buf.WriteByte('\n')
+	writeYAMLValue(buf, value, indent+2)
+}
+
+// yamlScalar formats a JSON scalar as a YAML scalar
+func yamlScalar(value any) string {
+	switch value := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		if value {
+			return "true"
+		}
+		return "false"
+	case json.Number:
+		return value.String()
+	case string:
+		if isPlainYAMLScalar(value) {
+			return value
+		}
+
+		// JSON strings are valid YAML double-quoted scalars
+		quoted, _ := json.Marshal(value)
+		return string(quoted)
+	default:
+		return ""
+	}
+}
+
+// isPlainYAMLScalar reports whether a string is read back as the same string without quotes
+func isPlainYAMLScalar(s string) bool {
+	if !plainYAMLScalar.MatchString(s) || strings.HasSuffix(s, " ") {
+		return false
+	}
+
+	switch strings.ToLower(s) {
+	case "y", "n", "yes", "no", "on", "off", "true", "false", "null":
+		return false
+	}
+
+	return true
+}
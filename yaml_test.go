@@ -0,0 +1,320 @@
+package fast
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestJSONToYAMLRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+	}{
+		{name: "scalars", json: `{"s":"text","i":42,"f":-1.5e3,"t":true,"f2":false,"n":null}`},
+		{name: "strings starting with digits", json: `{"a":"123","b":"1.0","c":"3.0.3","d":"1e3","e":"0x1F","f":"200 OK","g":"2024-01-01"}`},
+		{name: "comment and mapping indicators", json: `{"a":"#top","b":"a #b","c":"a:b","d":"a: b","e":":a","f":"a:"}`},
+		{name: "sequence and flow indicators", json: `{"a":"-","b":"-a","c":"- a","d":"[a]","e":"{a}","f":"a, b","g":"*a","h":"&a","i":"!a","j":"|","k":">","l":"%a","m":"@a","n":"` + "`a`" + `","o":"?"}`},
+		{name: "quotes and escapes", json: `{"a":"'a'","b":"\"a\"","c":"a\\b","d":"line\nbreak","e":"tab\there","f":"\u0001"}`},
+		{name: "YAML 1.1 booleans and nulls", json: `{"a":"yes","b":"No","c":"on","d":"OFF","e":"y","f":"n","g":"true","h":"null","i":"~","j":"Null"}`},
+		{name: "empty and blank strings", json: `{"a":"","b":" ","c":"a ","d":" a"}`},
+		{name: "non-ASCII", json: `{"a":"héllo","b":"✓","c":"日本語","d":"emoji 🚀","ключ":"значение"}`},
+		{name: "keys needing quotes", json: `{"200":"ok","":"empty","a b":"c","-":"dash","#":"hash","yes":"key","/users/{id}":"path","$ref":"ref","a:b":"colon"}`},
+		{name: "nested empty values", json: `{"a":[],"b":{},"c":[[],{},[[]],[{}]],"d":{"e":{},"f":[]}}`},
+		{name: "nested sequences", json: `{"a":[[1,2],[["x"]],[{"b":[1]}]],"c":[{"d":1,"e":{"f":[{"g":"h"}]}}]}`},
+		{name: "top level sequence", json: `[1,"a",{"b":"c"},[],{}]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := jsonToYAML([]byte(tt.json))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := parseTestYAML(string(out))
+			if err != nil {
+				t.Fatalf("invalid YAML: %s\n%s", err, out)
+			}
+
+			dec := json.NewDecoder(strings.NewReader(tt.json))
+			dec.UseNumber()
+
+			var want any
+			if err := dec.Decode(&want); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("round trip = %#v, want %#v\n%s", got, want, out)
+			}
+		})
+	}
+}
+
+func TestJSONToYAMLKeepsKeyOrder(t *testing.T) {
+	out, err := jsonToYAML([]byte(`{"z":1,"a":{"x":2,"b":3}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "z: 1\na:\n  x: 2\n  b: 3\n"
+	if string(out) != want {
+		t.Errorf("yaml = %q, want %q", out, want)
+	}
+}
+
+// yamlLine is a non-empty line of a YAML document
+type yamlLine struct {
+	indent  int
+	content string
+}
+
+// testYAMLParser reads the block YAML written by jsonToYAML, resolving
+// plain scalars as a YAML 1.1 parser would, so a string written without
+// quotes that reads back as another type or value fails the round trip
+type testYAMLParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+var (
+	yamlNumber      = regexp.MustCompile(`^[-+]?(\.[0-9]+|[0-9][0-9_]*(\.[0-9_]*)?)([eE][-+]?[0-9]+)?$|^0x[0-9a-fA-F_]+$|^0o?[0-7_]+$|^[-+]?\.(inf|Inf|INF)$|^\.(nan|NaN|NAN)$|^[0-9]+(:[0-5]?[0-9])+$`)
+	yamlTimestamp   = regexp.MustCompile(`^[0-9]{4}-[0-9]{1,2}-[0-9]{1,2}`)
+	yamlIndicators  = "-?:,[]{}#&*!|>'\"%@`"
+	yamlBooleans    = []string{"y", "n", "yes", "no", "on", "off", "true", "false"}
+	yamlNullScalars = []string{"", "~", "null"}
+)
+
+func parseTestYAML(doc string) (any, error) {
+	p := &testYAMLParser{}
+
+	for _, line := range strings.Split(doc, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		content := strings.TrimLeft(line, " ")
+		p.lines = append(p.lines, yamlLine{indent: len(line) - len(content), content: content})
+	}
+
+	if len(p.lines) == 0 {
+		return nil, fmt.Errorf("empty document")
+	}
+
+	value, err := p.node(p.lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos != len(p.lines) {
+		return nil, fmt.Errorf("line %q: unexpected indentation", p.lines[p.pos].content)
+	}
+
+	return value, nil
+}
+
+// node reads the mapping or sequence starting at the current line
+func (p *testYAMLParser) node(indent int) (any, error) {
+	line := p.lines[p.pos]
+	if line.indent != indent {
+		return nil, fmt.Errorf("line %q: indentation %d, want %d", line.content, line.indent, indent)
+	}
+
+	if line.content == "-" || strings.HasPrefix(line.content, "- ") {
+		return p.sequence(indent)
+	}
+
+	return p.mapping(indent)
+}
+
+func (p *testYAMLParser) sequence(indent int) (any, error) {
+	items := []any{}
+
+	for p.pos < len(p.lines) && p.lines[p.pos].indent == indent {
+		line := p.lines[p.pos]
+		if line.content != "-" && !strings.HasPrefix(line.content, "- ") {
+			return nil, fmt.Errorf("line %q: expected a sequence item", line.content)
+		}
+
+		rest := strings.TrimPrefix(strings.TrimPrefix(line.content, "-"), " ")
+
+		var (
+			item any
+			err  error
+		)
+
+		switch {
+		case rest == "":
+			item, err = p.child(indent)
+		case isTestYAMLMappingLine(rest):
+			// The first key of a mapping item follows the dash
+			p.lines[p.pos] = yamlLine{indent: indent + 2, content: rest}
+			item, err = p.mapping(indent + 2)
+		default:
+			item, err = testYAMLValue(rest)
+			p.pos++
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+func (p *testYAMLParser) mapping(indent int) (any, error) {
+	entries := map[string]any{}
+
+	for p.pos < len(p.lines) && p.lines[p.pos].indent == indent {
+		line := p.lines[p.pos]
+
+		key, rest, err := testYAMLKey(line.content)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, exists := entries[key]; exists {
+			return nil, fmt.Errorf("line %q: duplicate key", line.content)
+		}
+
+		var value any
+		if rest == "" {
+			value, err = p.child(indent)
+		} else {
+			value, err = testYAMLValue(rest)
+			p.pos++
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		entries[key] = value
+	}
+
+	return entries, nil
+}
+
+// child reads the block value of the key or dash on the current line
+func (p *testYAMLParser) child(indent int) (any, error) {
+	p.pos++
+
+	if p.pos >= len(p.lines) || p.lines[p.pos].indent <= indent {
+		return nil, fmt.Errorf("missing value after line %q", p.lines[p.pos-1].content)
+	}
+
+	return p.node(p.lines[p.pos].indent)
+}
+
+// isTestYAMLMappingLine reports whether a line starts with a key
+func isTestYAMLMappingLine(content string) bool {
+	_, _, err := testYAMLKey(content)
+	return err == nil
+}
+
+// testYAMLKey splits a mapping line into its key and the value that follows it
+func testYAMLKey(content string) (string, string, error) {
+	var (
+		raw  string
+		rest string
+	)
+
+	if strings.HasPrefix(content, `"`) {
+		dec := json.NewDecoder(strings.NewReader(content))
+
+		var key string
+		if err := dec.Decode(&key); err != nil {
+			return "", "", err
+		}
+
+		offset := int(dec.InputOffset())
+		raw, rest = content[:offset], content[offset:]
+	} else {
+		idx := strings.Index(content, ":")
+		if idx < 0 {
+			return "", "", fmt.Errorf("line %q: missing key", content)
+		}
+		raw, rest = content[:idx], content[idx:]
+	}
+
+	if rest != ":" && !strings.HasPrefix(rest, ": ") {
+		return "", "", fmt.Errorf("line %q: missing key", content)
+	}
+
+	key, err := testYAMLScalar(raw)
+	if err != nil {
+		return "", "", err
+	}
+
+	text, ok := key.(string)
+	if !ok {
+		return "", "", fmt.Errorf("line %q: key %#v is not a string", content, key)
+	}
+
+	return text, strings.TrimPrefix(strings.TrimPrefix(rest, ":"), " "), nil
+}
+
+// testYAMLValue reads an inline value
+func testYAMLValue(raw string) (any, error) {
+	switch raw {
+	case "{}":
+		return map[string]any{}, nil
+	case "[]":
+		return []any{}, nil
+	}
+
+	return testYAMLScalar(raw)
+}
+
+// testYAMLScalar resolves a scalar, plain ones with the YAML 1.1 types
+func testYAMLScalar(raw string) (any, error) {
+	if strings.HasPrefix(raw, `"`) {
+		dec := json.NewDecoder(strings.NewReader(raw))
+
+		var text string
+		if err := dec.Decode(&text); err != nil {
+			return nil, fmt.Errorf("scalar %q: %w", raw, err)
+		}
+
+		// Nothing may follow the closing quote
+		if int(dec.InputOffset()) != len(raw) {
+			return nil, fmt.Errorf("scalar %q: trailing characters", raw)
+		}
+
+		return text, nil
+	}
+
+	lower := strings.ToLower(raw)
+
+	switch {
+	case slices.Contains(yamlNullScalars, lower):
+		return nil, nil
+	case lower == "true":
+		return true, nil
+	case lower == "false":
+		return false, nil
+	case slices.Contains(yamlBooleans, lower):
+		return nil, fmt.Errorf("scalar %q reads as a YAML 1.1 boolean", raw)
+	case yamlNumber.MatchString(raw):
+		if !json.Valid([]byte(raw)) {
+			return nil, fmt.Errorf("scalar %q reads as a YAML number", raw)
+		}
+		return json.Number(raw), nil
+	case yamlTimestamp.MatchString(raw):
+		return nil, fmt.Errorf("scalar %q reads as a YAML timestamp", raw)
+	case strings.ContainsAny(raw[:1], yamlIndicators) || raw[0] == ' ':
+		return nil, fmt.Errorf("scalar %q starts with an indicator", raw)
+	case strings.Contains(raw, ": ") || strings.Contains(raw, " #") || strings.HasSuffix(raw, ":") || strings.HasSuffix(raw, " "):
+		return nil, fmt.Errorf("scalar %q is not a valid plain scalar", raw)
+	}
+
+	return raw, nil
+}